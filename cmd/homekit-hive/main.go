@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/brutella/hc"
@@ -28,7 +29,9 @@ func main() {
 		homekitPIN   string
 		storagePath  string
 		addr         string
+		sessionPath  string
 		debugLogging bool
+		debugBodies  bool
 	)
 
 	flag.StringVar(&username, "u", os.Getenv("HIVE_USERNAME"), "hive username")
@@ -36,7 +39,9 @@ func main() {
 	flag.StringVar(&homekitPIN, "pin", os.Getenv("HOMEKIT_PIN"), "homekit pin")
 	flag.StringVar(&storagePath, "path", os.Getenv("STORAGE_PATH"), "storage path, defaults to \"Hive Thermostat\"")
 	flag.StringVar(&addr, "listen", os.Getenv("LISTEN_ADDR"), "listen address ip:port, defaults to :0")
+	flag.StringVar(&sessionPath, "session", envOr("HIVE_SESSION_PATH", defaultSessionPath()), "hive session file, empty to disable")
 	flag.BoolVar(&debugLogging, "debug", false, "enable debug logging")
+	flag.BoolVar(&debugBodies, "debug-bodies", false, "include hive request and response bodies in debug logging")
 	flag.Parse()
 
 	logger := newLogger()
@@ -44,10 +49,17 @@ func main() {
 		logger.SetLevel(logrus.DebugLevel)
 	}
 
-	home, err := hive.Connect(
+	options := []hive.Option{
 		hive.WithCredentials(username, password),
-		hive.WithHTTPClient(httpClient()),
-	)
+		hive.WithHTTPClient(httpClient(logger, debugLogging, debugBodies)),
+		hive.WithRetryPolicy(hive.DefaultRetryPolicy),
+	}
+
+	if sessionPath != "" {
+		options = append(options, hive.WithSessionStore(&hive.FileSessionStore{Path: sessionPath}))
+	}
+
+	home, err := hive.Connect(options...)
 	if err != nil {
 		logger.Fatal(err)
 	}
@@ -94,6 +106,11 @@ func main() {
 	hc.OnTermination(func() {
 		<-transport.Stop()
 		logger.Infof("transport stopped")
+
+		cancel()
+		if err := home.Close(); err != nil {
+			logger.Errorf("failed to logout of hive: %v", err)
+		}
 	})
 
 	printPIN(homekitPIN)
@@ -110,6 +127,7 @@ func newAccessory(info accessory.Info, t *thermostat) *accessory.Thermostat {
 	acc.Thermostat.CurrentTemperature.OnValueRemoteGet(t.getTemp)
 
 	acc.Thermostat.TargetHeatingCoolingState.OnValueRemoteGet(t.getMode)
+	acc.Thermostat.CurrentHeatingCoolingState.OnValueRemoteGet(t.getState)
 
 	battery := service.NewBatteryService()
 	battery.BatteryLevel.SetMinValue(0)
@@ -120,20 +138,43 @@ func newAccessory(info accessory.Info, t *thermostat) *accessory.Thermostat {
 	return acc
 }
 
-func httpClient() *http.Client {
+func httpClient(logger *logrus.Logger, debug, bodies bool) *http.Client {
+	var rt http.RoundTripper = &http.Transport{
+		Dial: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).Dial,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+	}
+
+	if debug {
+		rt = httpkit.LoggingTransport(httpkit.LoggerFunc(logger.Debugf), bodies, rt)
+	}
+
 	userAgent := version.HTTPUserAgent("homekit-hive")
 	return &http.Client{
-		Transport: httpkit.UserAgentTransport(userAgent, &http.Transport{
-			Dial: (&net.Dialer{
-				Timeout:   30 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).Dial,
-			TLSHandshakeTimeout:   10 * time.Second,
-			ResponseHeaderTimeout: 10 * time.Second,
-		}),
+		Transport: httpkit.UserAgentTransport(userAgent, rt),
 	}
 }
 
+func envOr(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+
+	return def
+}
+
+func defaultSessionPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "homekit-hive", "session.json")
+}
+
 func newLogger() *logrus.Logger {
 	logger := logrus.StandardLogger()
 	logger.SetFormatter(&logrus.TextFormatter{
@@ -157,19 +198,31 @@ func printPIN(pin string) {
 	fmt.Printf("      └────────────┘\n\n")
 }
 
+// staleThreshold is how long the thermostat may go unseen before its
+// readings are considered stale
+const staleThreshold = 30 * time.Minute
+
 func pollForHiveUpdates(ctx context.Context, thermostat *thermostat, acc *accessory.Thermostat, logger *logrus.Logger) {
 	tick := time.NewTicker(1 * time.Minute)
 
 	for {
-		if err := thermostat.update(); err != nil {
+		if err := thermostat.update(ctx); err != nil {
+			if ctx.Err() != nil {
+				tick.Stop()
+				return
+			}
+
 			logger.Errorf("failed to update thermostat: %v", err)
-			continue
+		} else {
+			if !thermostat.hive.IsOnline(staleThreshold) {
+				logger.Warnf("thermostat %v is offline or stale, last seen %v", thermostat.ID(), thermostat.hive.LastSeen())
+			}
+
+			acc.Thermostat.TargetTemperature.SetValue(thermostat.getTarget())
+			acc.Thermostat.CurrentTemperature.SetValue(thermostat.getTemp())
+			acc.Thermostat.CurrentHeatingCoolingState.SetValue(thermostat.getState())
 		}
 
-		acc.Thermostat.TargetTemperature.SetValue(thermostat.getTarget())
-		acc.Thermostat.CurrentTemperature.SetValue(thermostat.getTemp())
-		acc.Thermostat.CurrentHeatingCoolingState.SetValue(thermostat.getMode())
-
 		select {
 		case <-tick.C:
 		case <-ctx.Done():
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"sync"
 
 	"github.com/brutella/hc/characteristic"
@@ -10,6 +12,7 @@ import (
 )
 
 type thermostat struct {
+	home   *hive.Home
 	hive   *hive.Thermostat
 	ui     *hive.Controller
 	logger *logrus.Logger
@@ -40,41 +43,48 @@ func newThermostat(home *hive.Home, logger *logrus.Logger) (*thermostat, error)
 	}
 
 	return &thermostat{
+		home:    home,
 		hive:    t,
 		ui:      c,
 		logger:  logger,
 		cur:     cur,
 		min:     t.Minimum(),
 		max:     t.Maximum(),
-		step:    0.5,
+		step:    t.Step(),
 		battery: batt,
 	}, nil
 }
 
 func hiveComponents(home *hive.Home) (*hive.Thermostat, *hive.Controller, error) {
-	thermostats, err := home.Thermostats()
+	zones, err := home.Zones()
 	if err != nil {
 		return nil, nil, err
 	}
 
-	controllers, err := home.Controllers()
-	if err != nil {
-		return nil, nil, err
+	for _, z := range zones {
+		if z.Controller != nil {
+			return z.Thermostat, z.Controller, nil
+		}
 	}
 
-	return thermostats[0], controllers[0], nil
+	return nil, nil, errors.New("no thermostat paired with a controller found")
 }
 
 func (t *thermostat) ID() string {
 	return t.hive.ID
 }
 
-func (t *thermostat) update() error {
-	if err := t.hive.Update(); err != nil {
+func (t *thermostat) update(ctx context.Context) error {
+	if err := t.home.RefreshContext(ctx); err != nil {
 		return err
 	}
 
-	return t.ui.Update()
+	// other nodes coming and going is fine, only our own devices matter
+	if !t.home.Tracking(t.hive) || !t.home.Tracking(t.ui) {
+		return errors.New("thermostat or controller missing from hive update")
+	}
+
+	return nil
 }
 
 func (t *thermostat) setTarget(newTemp float64) {
@@ -84,6 +94,14 @@ func (t *thermostat) setTarget(newTemp float64) {
 }
 
 func (t *thermostat) getTarget() float64 {
+	// report a requested target until the thermostat confirms it, rather
+	// than snapping back to the previous target
+	if pc, ok := t.hive.Pending("targetHeatTemperature"); ok {
+		if temp, ok := pc.TargetValueFloat(); ok {
+			return temp
+		}
+	}
+
 	temp, err := t.hive.Target()
 	if err != nil {
 		t.logger.Errorf("failed to retrieve target temperature from API: %v", err)
@@ -123,14 +141,27 @@ func (t *thermostat) getMode() int {
 
 	switch mode {
 	case hive.ActiveModeHeating:
-		return characteristic.CurrentHeatingCoolingStateHeat
+		return characteristic.TargetHeatingCoolingStateHeat
 	case hive.ActiveModeCooling:
-		return characteristic.CurrentHeatingCoolingStateCool
+		return characteristic.TargetHeatingCoolingStateCool
 	default:
-		return characteristic.CurrentHeatingCoolingStateOff
+		return characteristic.TargetHeatingCoolingStateOff
 	}
 }
 
+func (t *thermostat) getState() int {
+	heating, err := t.hive.Heating()
+	if err != nil {
+		t.logger.Errorf("failed to retrieve heating state from API: %v", err)
+	}
+
+	if heating {
+		return characteristic.CurrentHeatingCoolingStateHeat
+	}
+
+	return characteristic.CurrentHeatingCoolingStateOff
+}
+
 func (t *thermostat) getBatteryLevel() int {
 	batt, err := t.ui.BatteryLevel()
 
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/geoffgarside/homekit-hive/pkg/api/v6/hive"
 )
@@ -13,6 +17,9 @@ func main() {
 		username string
 		password string
 		setTemp  float64
+		boost    time.Duration
+		session  string
+		logout   bool
 	)
 
 	flag.StringVar(&username, "username", "", "hive username")
@@ -20,17 +27,45 @@ func main() {
 	flag.StringVar(&username, "u", "", "hive username")
 	flag.StringVar(&password, "p", "", "hive password")
 	flag.Float64Var(&setTemp, "set", 0, "Set temperature")
+	flag.DurationVar(&boost, "boost", 0, "Boost to the -set temperature for duration")
+	flag.StringVar(&session, "session", defaultSessionPath(), "hive session file, empty to disable")
+	flag.BoolVar(&logout, "logout", false, "end the hive session on exit instead of keeping it for the next run")
 	flag.Parse()
 
-	c, err := hive.Connect(hive.WithCredentials(username, password))
+	options := []hive.Option{
+		hive.WithCredentials(username, password),
+	}
+
+	if session != "" {
+		options = append(options, hive.WithSessionStore(&hive.FileSessionStore{Path: session}))
+	}
+
+	c, err := hive.Connect(options...)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	ts, err := c.Thermostats()
+	err = run(c, setTemp, boost)
+
+	// A persisted session is kept for the next invocation unless asked
+	// to log out, otherwise end it rather than leaving it open on the
+	// account.
+	if logout || session == "" {
+		if err := c.Close(); err != nil {
+			log.Print(err)
+		}
+	}
+
 	if err != nil {
 		log.Fatal(err)
 	}
+}
+
+func run(c *hive.Home, setTemp float64, boost time.Duration) error {
+	ts, err := c.Thermostats()
+	if err != nil {
+		return err
+	}
 
 	for _, t := range ts {
 		currentTemp, err := t.Temperature()
@@ -47,9 +82,31 @@ func main() {
 			t.ID, t.Name, currentTemp, targetTemp)
 	}
 
-	if setTemp > 0 {
-		if err := ts[0].SetTarget(setTemp); err != nil {
-			log.Fatal(err)
+	if (boost > 0 || setTemp > 0) && len(ts) == 0 {
+		return errors.New("no thermostats found")
+	}
+
+	switch {
+	case boost > 0:
+		if setTemp == 0 {
+			if setTemp, err = ts[0].Target(); err != nil {
+				return err
+			}
 		}
+
+		return ts[0].Boost(setTemp, boost)
+	case setTemp > 0:
+		return ts[0].SetTarget(setTemp)
 	}
+
+	return nil
+}
+
+func defaultSessionPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "homekit-hive", "session.json")
 }
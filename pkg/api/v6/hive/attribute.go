@@ -0,0 +1,112 @@
+package hive
+
+import "time"
+
+// Attribute is a read-only view of a single attribute of a Node. Each
+// attribute carries the value reported by the device, the value shown
+// to users and, while a change is pending, the requested target value.
+type Attribute struct {
+	attr *nodeAttribute
+
+	Name string
+}
+
+// ReportedValue returns the raw value reported by the device
+func (a *Attribute) ReportedValue() interface{} {
+	return a.attr.ReportedValue
+}
+
+// DisplayValue returns the raw value displayed to users
+func (a *Attribute) DisplayValue() interface{} {
+	return a.attr.DisplayValue
+}
+
+// TargetValue returns the raw value requested of the device
+func (a *Attribute) TargetValue() interface{} {
+	return a.attr.TargetValue
+}
+
+// ReportReceived returns when the device last reported the attribute
+func (a *Attribute) ReportReceived() time.Time {
+	return millisTime(a.attr.ReportReceivedTime)
+}
+
+// ReportChanged returns when the reported value last changed
+func (a *Attribute) ReportChanged() time.Time {
+	return millisTime(a.attr.ReportChangedTime)
+}
+
+// ReportedValueString returns the reported value if it is a string
+func (a *Attribute) ReportedValueString() (string, bool) {
+	return a.attr.ReportedValueString()
+}
+
+// ReportedValueBool returns the reported value if it is a bool
+func (a *Attribute) ReportedValueBool() (bool, bool) {
+	return a.attr.ReportedValueBool()
+}
+
+// ReportedValueFloat returns the reported value if it is a number
+func (a *Attribute) ReportedValueFloat() (float64, bool) {
+	return a.attr.ReportedValueFloat()
+}
+
+// ReportedValueInt returns the reported value if it is an integer
+func (a *Attribute) ReportedValueInt() (int64, bool) {
+	return a.attr.ReportedValueInt()
+}
+
+// ReportedValueUint returns the reported value if it is an unsigned integer
+func (a *Attribute) ReportedValueUint() (uint64, bool) {
+	return a.attr.ReportedValueUint()
+}
+
+// DisplayValueString returns the display value if it is a string
+func (a *Attribute) DisplayValueString() (string, bool) {
+	return a.attr.DisplayValueString()
+}
+
+// DisplayValueBool returns the display value if it is a bool
+func (a *Attribute) DisplayValueBool() (bool, bool) {
+	return a.attr.DisplayValueBool()
+}
+
+// DisplayValueFloat returns the display value if it is a number
+func (a *Attribute) DisplayValueFloat() (float64, bool) {
+	return a.attr.DisplayValueFloat()
+}
+
+// DisplayValueInt returns the display value if it is an integer
+func (a *Attribute) DisplayValueInt() (int64, bool) {
+	return a.attr.DisplayValueInt()
+}
+
+// DisplayValueUint returns the display value if it is an unsigned integer
+func (a *Attribute) DisplayValueUint() (uint64, bool) {
+	return a.attr.DisplayValueUint()
+}
+
+// TargetValueString returns the target value if it is a string
+func (a *Attribute) TargetValueString() (string, bool) {
+	return a.attr.TargetValueString()
+}
+
+// TargetValueBool returns the target value if it is a bool
+func (a *Attribute) TargetValueBool() (bool, bool) {
+	return a.attr.TargetValueBool()
+}
+
+// TargetValueFloat returns the target value if it is a number
+func (a *Attribute) TargetValueFloat() (float64, bool) {
+	return a.attr.TargetValueFloat()
+}
+
+// TargetValueInt returns the target value if it is an integer
+func (a *Attribute) TargetValueInt() (int64, bool) {
+	return a.attr.TargetValueInt()
+}
+
+// TargetValueUint returns the target value if it is an unsigned integer
+func (a *Attribute) TargetValueUint() (uint64, bool) {
+	return a.attr.TargetValueUint()
+}
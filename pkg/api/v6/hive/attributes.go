@@ -0,0 +1,58 @@
+package hive
+
+import "context"
+
+// SetAttributes requests new target values for the named attributes of
+// the device, refreshing every device handed out by the Home for the
+// same node with the node returned by the API.
+func (home *Home) SetAttributes(d Device, attrs map[string]interface{}) error {
+	return home.SetAttributesContext(context.Background(), d, attrs)
+}
+
+// SetAttributesContext requests new target values for the named
+// attributes of the device using the provided context, refreshing every
+// device handed out by the Home for the same node with the node
+// returned by the API.
+func (home *Home) SetAttributesContext(ctx context.Context, d Device, attrs map[string]interface{}) error {
+	if len(attrs) == 0 {
+		return &Error{
+			Op:      "home: set attributes",
+			Code:    ErrInvalidUpdate,
+			Message: "no attributes to set",
+		}
+	}
+
+	na := make(nodeAttributes, len(attrs))
+	for name, v := range attrs {
+		na[name] = &nodeAttribute{TargetValue: v}
+	}
+
+	return home.setAttributes(ctx, "home: set attributes", d, na)
+}
+
+// setAttributes sets the target values of attrs on the node of d, every
+// write to the API goes through here.
+func (home *Home) setAttributes(ctx context.Context, op string, d device, attrs nodeAttributes) error {
+	n, err := home.setNode(ctx, op, d.deviceHref(), attrs)
+	if err != nil {
+		return err
+	}
+
+	d.refresh(n)
+	home.refreshNode(n)
+
+	return nil
+}
+
+// refreshNode updates every other device handed out by the Home which
+// wraps the same node as n.
+func (home *Home) refreshNode(n *node) {
+	home.devicesMu.Lock()
+	defer home.devicesMu.Unlock()
+
+	for key, d := range home.devices {
+		if key.id == n.ID {
+			d.refresh(n)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package hive_test
+
+import (
+	"testing"
+
+	"github.com/geoffgarside/homekit-hive/pkg/api/v6/hive"
+	"github.com/geoffgarside/homekit-hive/pkg/api/v6/hive/hivetest"
+)
+
+func TestHome_SetAttributes(t *testing.T) {
+	srv := hivetest.NewServer("username", "password")
+	defer srv.Close()
+
+	srv.AddNode(hivetest.Thermostat("t1", "Heating", 19.5, 20))
+
+	home, err := hive.Connect(
+		hive.WithCredentials("username", "password"),
+		hive.WithHTTPClient(srv.Client()),
+		hive.WithURL(srv.URL),
+	)
+	if err != nil {
+		t.Fatalf("hive.Connect() error = %v, want nil", err)
+	}
+
+	thermostats, err := home.Thermostats()
+	if err != nil || len(thermostats) != 1 {
+		t.Fatalf("Home.Thermostats() = %v, %v, want 1 thermostat", thermostats, err)
+	}
+
+	nodes, err := home.Nodes()
+	if err != nil || len(nodes) != 1 {
+		t.Fatalf("Home.Nodes() = %v, %v, want 1 node", nodes, err)
+	}
+
+	if err := home.SetAttributes(nodes[0], nil); hive.ErrorCode(err) != hive.ErrInvalidUpdate {
+		t.Errorf("Home.SetAttributes() error = %v, want %v", err, hive.ErrInvalidUpdate)
+	}
+
+	if err := home.SetAttributes(nodes[0], map[string]interface{}{
+		"targetHeatTemperature": 22.5,
+		"activeScheduleLock":    false,
+	}); err != nil {
+		t.Fatalf("Home.SetAttributes() error = %v, want nil", err)
+	}
+
+	if got := srv.Attribute("t1", "activeScheduleLock"); got.ReportedValue != false {
+		t.Errorf("Server.Attribute() = %+v, want reported false", got)
+	}
+
+	// the Thermostat wraps the same node and is refreshed along with it
+	if got, _ := thermostats[0].Target(); got != 22.5 {
+		t.Errorf("Thermostat.Target() = %v, want 22.5", got)
+	}
+
+	if got, _ := thermostats[0].Mode(); got != hive.ThermostatModeSchedule {
+		t.Errorf("Thermostat.Mode() = %v, want %v", got, hive.ThermostatModeSchedule)
+	}
+
+	if a, _ := nodes[0].Attribute("targetHeatTemperature"); a == nil {
+		t.Errorf("Node.Attribute() = nil, want attribute")
+	} else if v, _ := a.ReportedValueFloat(); v != 22.5 {
+		t.Errorf("Attribute.ReportedValueFloat() = %v, want 22.5", v)
+	}
+}
@@ -0,0 +1,214 @@
+package hive
+
+import (
+	"context"
+	"time"
+)
+
+// previousConfiguration returns the mode and target temperature the node
+// was in before the current boost started, as reported by the API.
+func (n *node) previousConfiguration() (mode string, target float64, hasTarget bool) {
+	var prev struct {
+		Mode   string   `json:"mode"`
+		Target *float64 `json:"targetHeatTemperature"`
+	}
+
+	attr := n.attr("previousConfiguration")
+	if attr.ReportedValue == nil || attr.decodeReportedValue(&prev) != nil {
+		return "", 0, false
+	}
+
+	if prev.Target == nil {
+		return prev.Mode, 0, false
+	}
+
+	return prev.Mode, *prev.Target, true
+}
+
+// boostRemaining returns the time left on the nodes current boost
+func (n *node) boostRemaining(op string) (time.Duration, error) {
+	mode, ok := n.attr("activeHeatCoolMode").ReportedValueString()
+	if !ok {
+		return 0, &Error{
+			Op:      op,
+			Code:    ErrInvalidDataType,
+			Message: "invalid data type",
+		}
+	}
+
+	if mode != "BOOST" {
+		return 0, nil
+	}
+
+	mins, ok := n.attr("scheduleLockDuration").ReportedValueFloat()
+	if !ok {
+		return 0, &Error{
+			Op:      op,
+			Code:    ErrInvalidDataType,
+			Message: "invalid data type",
+		}
+	}
+
+	return time.Duration(mins * float64(time.Minute)), nil
+}
+
+// boostMinutes converts d into the whole number of minutes the API expects
+func boostMinutes(op string, d time.Duration) (int, error) {
+	mins := int(d.Round(time.Minute) / time.Minute)
+	if mins < 1 {
+		return 0, &Error{
+			Op:      op,
+			Code:    ErrInvalidDuration,
+			Message: "boost duration must be at least one minute",
+		}
+	}
+
+	return mins, nil
+}
+
+// Boosting returns true if the thermostat is currently being boosted
+func (t *Thermostat) Boosting() (bool, error) {
+	v, ok := t.current().attr("activeHeatCoolMode").ReportedValueString()
+	if !ok {
+		return false, &Error{
+			Op:      "thermostat: boosting",
+			Code:    ErrInvalidDataType,
+			Message: "invalid data type",
+		}
+	}
+
+	return v == "BOOST", nil
+}
+
+// BoostRemaining returns the time left on the current boost, or zero if
+// the thermostat is not being boosted.
+func (t *Thermostat) BoostRemaining() (time.Duration, error) {
+	return t.current().boostRemaining("thermostat: boost remaining")
+}
+
+// Boost heats to temp for the given duration, after which the
+// thermostat returns to its previous mode.
+func (t *Thermostat) Boost(temp float64, d time.Duration) error {
+	return t.BoostContext(context.Background(), temp, d)
+}
+
+// BoostContext heats to temp for the given duration using the provided
+// context, after which the thermostat returns to its previous mode.
+func (t *Thermostat) BoostContext(ctx context.Context, temp float64, d time.Duration) error {
+	mins, err := boostMinutes("thermostat: boost", d)
+	if err != nil {
+		return err
+	}
+
+	temp, err = t.setpoint("thermostat: boost", temp)
+	if err != nil {
+		return err
+	}
+
+	return t.home.setAttributes(ctx, "thermostat: boost", t, nodeAttributes{
+		"activeHeatCoolMode":    {TargetValue: "BOOST"},
+		"scheduleLockDuration":  {TargetValue: mins},
+		"targetHeatTemperature": {TargetValue: temp},
+	})
+}
+
+// CancelBoost ends the current boost and restores the mode and target
+// temperature the thermostat had before the boost started.
+func (t *Thermostat) CancelBoost() error {
+	return t.CancelBoostContext(context.Background())
+}
+
+// CancelBoostContext ends the current boost using the provided context
+// and restores the mode and target temperature the thermostat had
+// before the boost started.
+func (t *Thermostat) CancelBoostContext(ctx context.Context) error {
+	boosting, err := t.Boosting()
+	if err != nil || !boosting {
+		return err
+	}
+
+	prev, target, hasTarget := t.current().previousConfiguration()
+
+	var mode ThermostatMode
+
+	switch prev {
+	case "OFF":
+		mode = ThermostatModeOff
+	case "MANUAL":
+		mode = ThermostatModeManual
+	default:
+		mode = ThermostatModeSchedule
+	}
+
+	attrs, err := t.modeAttributes(mode)
+	if err != nil {
+		return err
+	}
+
+	if hasTarget && mode != ThermostatModeOff {
+		attrs["targetHeatTemperature"] = &nodeAttribute{TargetValue: target}
+	}
+
+	return t.home.setAttributes(ctx, "thermostat: cancel boost", t, attrs)
+}
+
+// BoostRemaining returns the time left on the current boost, or zero if
+// the hot water is not being boosted.
+func (hw *HotWater) BoostRemaining() (time.Duration, error) {
+	return hw.current().boostRemaining("hot water: boost remaining")
+}
+
+// Boost turns the hot water on for the given duration, after which it
+// returns to its previous mode.
+func (hw *HotWater) Boost(d time.Duration) error {
+	return hw.BoostContext(context.Background(), d)
+}
+
+// BoostContext turns the hot water on for the given duration using the
+// provided context, after which it returns to its previous mode.
+func (hw *HotWater) BoostContext(ctx context.Context, d time.Duration) error {
+	mins, err := boostMinutes("hot water: boost", d)
+	if err != nil {
+		return err
+	}
+
+	return hw.home.setAttributes(ctx, "hot water: boost", hw, nodeAttributes{
+		"activeHeatCoolMode":   {TargetValue: "BOOST"},
+		"scheduleLockDuration": {TargetValue: mins},
+	})
+}
+
+// CancelBoost ends the current boost and restores the mode the hot
+// water had before the boost started.
+func (hw *HotWater) CancelBoost() error {
+	return hw.CancelBoostContext(context.Background())
+}
+
+// CancelBoostContext ends the current boost using the provided context
+// and restores the mode the hot water had before the boost started.
+func (hw *HotWater) CancelBoostContext(ctx context.Context) error {
+	boosting, err := hw.Boosting()
+	if err != nil || !boosting {
+		return err
+	}
+
+	prev, _, _ := hw.current().previousConfiguration()
+
+	var mode HotWaterMode
+
+	switch prev {
+	case "OFF":
+		mode = HotWaterModeOff
+	case "MANUAL":
+		mode = HotWaterModeOn
+	default:
+		mode = HotWaterModeSchedule
+	}
+
+	attrs, err := hotWaterModeAttributes(mode)
+	if err != nil {
+		return err
+	}
+
+	return hw.home.setAttributes(ctx, "hot water: cancel boost", hw, attrs)
+}
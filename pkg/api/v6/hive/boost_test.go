@@ -0,0 +1,242 @@
+package hive
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+// echoNodeServer returns a server which echoes the target values of each PUT
+// back as reported values, recording the last request it received.
+func echoNodeServer(t *testing.T, id string, last *nodeAttributes) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			http.Error(w, "must be put", http.StatusBadRequest)
+			return
+		}
+
+		if r.URL.Path != "/omnia/nodes/"+id {
+			http.Error(w, "unknown path", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.alertme.zoo-6.1+json;charset=UTF-8")
+
+		var req nodesResponse
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Nodes) != 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors": [{"code": "INVALID_PARAMETER","title": "Node configuration error", "links": []}]}`))
+			return
+		}
+
+		*last = req.Nodes[0].Attributes
+
+		resp := &node{ID: id, Attributes: make(nodeAttributes)}
+		for k, v := range req.Nodes[0].Attributes {
+			resp.Attributes[k] = &nodeAttribute{ReportedValue: v.TargetValue}
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&nodesResponse{Nodes: []*node{resp}}); err != nil {
+			t.Errorf("encode response: %v", err)
+		}
+	}))
+}
+
+func TestThermostat_BoostRemaining(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     interface{}
+		duration interface{}
+		want     time.Duration
+		wantErr  bool
+	}{
+		{"Boosting", "BOOST", 30.0, 30 * time.Minute, false},
+		{"NotBoosting", "HEAT", 30.0, 0, false},
+		{"InvalidMode", 100, 30.0, 0, true},
+		{"InvalidDuration", "BOOST", "30", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &Thermostat{
+				node: &node{
+					Attributes: nodeAttributes{
+						"activeHeatCoolMode": &nodeAttribute{
+							ReportedValue: tt.mode,
+						},
+						"scheduleLockDuration": &nodeAttribute{
+							ReportedValue: tt.duration,
+						},
+					},
+				},
+			}
+			got, err := ts.BoostRemaining()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Thermostat.BoostRemaining() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Thermostat.BoostRemaining() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThermostat_Boost(t *testing.T) {
+	const id = "fe49e95e-c8cc-47cc-b38f-ec0c06361e13"
+
+	var last nodeAttributes
+	srv := echoNodeServer(t, id, &last)
+	defer srv.Close()
+
+	baseURL, _ := url.Parse(srv.URL)
+	ts := &Thermostat{
+		ID:   id,
+		Href: "https://api-prod.bgchprod.info/omnia/nodes/" + id,
+		home: &Home{baseURL: baseURL, httpClient: srv.Client()},
+		node: &node{},
+	}
+
+	if err := ts.Boost(22, 10*time.Second); ErrorCode(err) != ErrInvalidDuration {
+		t.Errorf("Thermostat.Boost() error = %v, want %v", err, ErrInvalidDuration)
+	}
+
+	if err := ts.Boost(22, 45*time.Minute); err != nil {
+		t.Fatalf("Thermostat.Boost() error = %v, want nil", err)
+	}
+
+	want := nodeAttributes{
+		"activeHeatCoolMode":    {TargetValue: "BOOST"},
+		"scheduleLockDuration":  {TargetValue: json.Number("45")},
+		"targetHeatTemperature": {TargetValue: json.Number("22")},
+	}
+	if diff := deep.Equal(last, want); diff != nil {
+		t.Errorf("Thermostat.Boost() request = %v, want %v, diff = %v", last, want, diff)
+	}
+
+	if got, _ := ts.BoostRemaining(); got != 45*time.Minute {
+		t.Errorf("Thermostat.BoostRemaining() = %v, want %v", got, 45*time.Minute)
+	}
+}
+
+func TestThermostat_CancelBoost(t *testing.T) {
+	const id = "fe49e95e-c8cc-47cc-b38f-ec0c06361e13"
+
+	var last nodeAttributes
+	srv := echoNodeServer(t, id, &last)
+	defer srv.Close()
+
+	baseURL, _ := url.Parse(srv.URL)
+	home := &Home{baseURL: baseURL, httpClient: srv.Client()}
+
+	tests := []struct {
+		name     string
+		previous interface{}
+		want     nodeAttributes
+	}{
+		{"Schedule", map[string]interface{}{"mode": "AUTO"}, nodeAttributes{
+			"activeHeatCoolMode": {TargetValue: "HEAT"},
+			"activeScheduleLock": {TargetValue: false},
+		}},
+		{"Manual", map[string]interface{}{"mode": "MANUAL", "targetHeatTemperature": 19.5}, nodeAttributes{
+			"activeHeatCoolMode":    {TargetValue: "HEAT"},
+			"activeScheduleLock":    {TargetValue: true},
+			"targetHeatTemperature": {TargetValue: json.Number("19.5")},
+		}},
+		{"Off", map[string]interface{}{"mode": "OFF", "targetHeatTemperature": 19.5}, nodeAttributes{
+			"activeHeatCoolMode":    {TargetValue: "OFF"},
+			"activeScheduleLock":    {TargetValue: true},
+			"targetHeatTemperature": {TargetValue: json.Number("7")},
+		}},
+		{"Missing", nil, nodeAttributes{
+			"activeHeatCoolMode": {TargetValue: "HEAT"},
+			"activeScheduleLock": {TargetValue: false},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			last = nil
+			ts := &Thermostat{
+				ID:   id,
+				Href: "https://api-prod.bgchprod.info/omnia/nodes/" + id,
+				home: home,
+				node: &node{
+					Attributes: nodeAttributes{
+						"activeHeatCoolMode":    {ReportedValue: "BOOST"},
+						"previousConfiguration": {ReportedValue: tt.previous},
+					},
+				},
+			}
+
+			if err := ts.CancelBoost(); err != nil {
+				t.Fatalf("Thermostat.CancelBoost() error = %v, want nil", err)
+			}
+
+			if diff := deep.Equal(last, tt.want); diff != nil {
+				t.Errorf("Thermostat.CancelBoost() request = %v, want %v, diff = %v", last, tt.want, diff)
+			}
+
+			if boosting, _ := ts.Boosting(); boosting {
+				t.Errorf("Thermostat.Boosting() = %v, want false", boosting)
+			}
+		})
+	}
+}
+
+func TestHotWater_Boost(t *testing.T) {
+	const id = "8a2b9a9c-7c3d-4b8e-bd1a-6e0a5d5b0c11"
+
+	var last nodeAttributes
+	srv := echoNodeServer(t, id, &last)
+	defer srv.Close()
+
+	baseURL, _ := url.Parse(srv.URL)
+	hw := &HotWater{
+		ID:   id,
+		Href: "https://api-prod.bgchprod.info/omnia/nodes/" + id,
+		home: &Home{baseURL: baseURL, httpClient: srv.Client()},
+	}
+
+	if err := hw.Boost(time.Hour); err != nil {
+		t.Fatalf("HotWater.Boost() error = %v, want nil", err)
+	}
+
+	want := nodeAttributes{
+		"activeHeatCoolMode":   {TargetValue: "BOOST"},
+		"scheduleLockDuration": {TargetValue: json.Number("60")},
+	}
+	if diff := deep.Equal(last, want); diff != nil {
+		t.Errorf("HotWater.Boost() request = %v, want %v, diff = %v", last, want, diff)
+	}
+
+	if got, _ := hw.BoostRemaining(); got != time.Hour {
+		t.Errorf("HotWater.BoostRemaining() = %v, want %v", got, time.Hour)
+	}
+
+	hw.node.Attributes["previousConfiguration"] = &nodeAttribute{
+		ReportedValue: map[string]interface{}{"mode": "MANUAL"},
+	}
+
+	if err := hw.CancelBoost(); err != nil {
+		t.Fatalf("HotWater.CancelBoost() error = %v, want nil", err)
+	}
+
+	if got, _ := hw.Mode(); got != HotWaterModeOn {
+		t.Errorf("HotWater.Mode() = %v, want %v", got, HotWaterModeOn)
+	}
+
+	last = nil
+	if err := hw.CancelBoost(); err != nil {
+		t.Errorf("HotWater.CancelBoost() not boosting error = %v, want nil", err)
+	}
+
+	if last != nil {
+		t.Errorf("HotWater.CancelBoost() request = %v, want none while not boosting", last)
+	}
+}
@@ -0,0 +1,39 @@
+package hive
+
+import "time"
+
+// clock provides the current time and tickers, allowing polling to be
+// driven deterministically in tests.
+type clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) ticker
+}
+
+type ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) NewTicker(d time.Duration) ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time { return t.t.C }
+func (t realTicker) Stop()               { t.t.Stop() }
+
+// clock returns the clock used by the Home
+func (home *Home) clock() clock {
+	if home == nil || home.clk == nil {
+		return realClock{}
+	}
+
+	return home.clk
+}
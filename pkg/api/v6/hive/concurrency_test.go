@@ -0,0 +1,149 @@
+package hive_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/geoffgarside/homekit-hive/pkg/api/v6/hive"
+)
+
+// TestHomeConcurrentSessionExpiry hammers a Home from many goroutines while
+// the session expires, run with -race to detect unsynchronised access.
+func TestHomeConcurrentSessionExpiry(t *testing.T) {
+	const nodeID = "fe49e95e-c8cc-47cc-b38f-ec0c06361e13"
+
+	var (
+		mu      sync.Mutex
+		logins  int
+		session string
+		target  = 17.0
+	)
+
+	writeNodes := func(w http.ResponseWriter) {
+		fmt.Fprintf(w, `{
+			"nodes": [{
+				"id": %q,
+				"href": "https://api-prod.bgchprod.info/omnia/nodes/%s",
+				"name": "Receiver 1",
+				"attributes": {
+					"nodeType": {"reportedValue": "http://alertme.com/schema/json/node.class.thermostat.json#"},
+					"temperature": {"reportedValue": 19.5},
+					"targetHeatTemperature": {"reportedValue": %v}
+				}
+			}]
+		}`, nodeID, nodeID, target)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/vnd.alertme.zoo-6.1+json;charset=UTF-8")
+
+		if r.Method == http.MethodPost && r.URL.Path == "/omnia/auth/sessions" {
+			logins++
+			session = fmt.Sprintf("session-%d", logins)
+			fmt.Fprintf(w, `{"sessions":[{"userId":"b3a1835b","latestSupportedApiVersion":"6","sessionId":%q}]}`, session)
+			return
+		}
+
+		if r.Header.Get("X-Omnia-Access-Token") != session {
+			http.Error(w,
+				`{"errors":[{"code":"NOT_AUTHORIZED","title":"Not authorized","links":[]}]}`,
+				http.StatusUnauthorized)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet && (r.URL.Path == "/omnia/nodes" || r.URL.Path == "/omnia/nodes/"+nodeID):
+			writeNodes(w)
+		case r.Method == http.MethodPut && r.URL.Path == "/omnia/nodes/"+nodeID:
+			var req struct {
+				Nodes []struct {
+					Attributes map[string]struct {
+						TargetValue float64 `json:"targetValue"`
+					} `json:"attributes"`
+				} `json:"nodes"`
+			}
+
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Nodes) != 1 {
+				http.Error(w, `{"errors":[{"code":"INVALID_PARAMETER","title":"bad request"}]}`, http.StatusBadRequest)
+				return
+			}
+
+			target = req.Nodes[0].Attributes["targetHeatTemperature"].TargetValue
+			writeNodes(w)
+		default:
+			http.Error(w, "unknown path", http.StatusNotFound)
+		}
+	}))
+
+	defer srv.Close()
+
+	home, err := hive.Connect(
+		hive.WithCredentials("username", "password"),
+		hive.WithHTTPClient(srv.Client()),
+		hive.WithURL(srv.URL),
+	)
+	if err != nil {
+		t.Fatalf("hive.Connect() error = %v, want nil", err)
+	}
+
+	thermostats, err := home.Thermostats()
+	if err != nil || len(thermostats) != 1 {
+		t.Fatalf("Home.Thermostats() = %v, %v, want one thermostat", thermostats, err)
+	}
+
+	ts := thermostats[0]
+
+	// Expire the session, every goroutine is rejected with it but only a
+	// single login must be made to replace it.
+	mu.Lock()
+	session = "expired"
+	mu.Unlock()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			var err error
+			switch i % 4 {
+			case 0:
+				err = ts.Update()
+			case 1:
+				err = ts.SetTarget(18 + float64(i)/10)
+			case 2:
+				err = home.Refresh()
+			case 3:
+				_, err = home.Thermostats()
+			}
+
+			if err != nil {
+				errs <- err
+			}
+
+			ts.Target()
+			ts.Temperature()
+			home.Session()
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("concurrent request error = %v, want nil", err)
+	}
+
+	if logins != 2 {
+		t.Errorf("logins = %v, want 2", logins)
+	}
+}
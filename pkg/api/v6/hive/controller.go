@@ -1,5 +1,7 @@
 package hive
 
+import "context"
+
 // Controller is the Hive Thermostat UI control unit
 type Controller struct {
 	home *Home
@@ -13,7 +15,7 @@ type Controller struct {
 // BatteryLevel returns the percentage of battery currently registered
 // by the Controller.
 func (c *Controller) BatteryLevel() (int, error) {
-	l, ok := c.node.attr("batteryLevel").ReportedValueFloat()
+	l, ok := c.current().attr("batteryLevel").ReportedValueFloat()
 	if !ok {
 		return 0, &Error{
 			Op:      "controller: battery level",
@@ -25,9 +27,15 @@ func (c *Controller) BatteryLevel() (int, error) {
 	return int(l), nil
 }
 
-// Update fetches the latest information about the Controllerr from the API
+// Update fetches the latest information about the Controller from the API
 func (c *Controller) Update() error {
-	n, err := c.home.node(c.Href)
+	return c.UpdateContext(context.Background())
+}
+
+// UpdateContext fetches the latest information about the Controller from
+// the API using the provided context.
+func (c *Controller) UpdateContext(ctx context.Context) error {
+	n, err := c.home.node(ctx, c.Href)
 	if err != nil {
 		return &Error{Op: "controller: update", Err: err}
 	}
@@ -36,19 +44,30 @@ func (c *Controller) Update() error {
 		return &Error{Op: "controller: update", Code: ErrInvalidUpdate, Message: "update failed, ID mismatch"}
 	}
 
-	c.node = n
+	c.refresh(n)
 	return nil
 }
 
 // Controllers returns the list of controllers in the Home
 func (home *Home) Controllers() ([]*Controller, error) {
-	const thermostatuiNodeType = "http://alertme.com/schema/json/node.class.thermostatui.json#"
+	return home.ControllersContext(context.Background())
+}
 
-	nodes, err := home.nodes()
+// ControllersContext returns the list of controllers in the Home using
+// the provided context.
+func (home *Home) ControllersContext(ctx context.Context) ([]*Controller, error) {
+	nodes, err := home.nodes(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	return home.newControllers(nodes), nil
+}
+
+// newControllers returns a Controller for each thermostat UI in nodes
+func (home *Home) newControllers(nodes []*node) []*Controller {
+	const thermostatuiNodeType = "http://alertme.com/schema/json/node.class.thermostatui.json#"
+
 	var controllers []*Controller
 
 	for _, n := range nodes {
@@ -62,14 +81,14 @@ func (home *Home) Controllers() ([]*Controller, error) {
 		}
 
 		n := n
-		controllers = append(controllers, &Controller{
+		controllers = append(controllers, home.track(&Controller{
 			ID:   n.ID,
 			Name: n.Name,
 			Href: n.Href,
 			home: home,
 			node: n,
-		})
+		}).(*Controller))
 	}
 
-	return controllers, nil
+	return controllers
 }
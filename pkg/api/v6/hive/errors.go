@@ -1,7 +1,10 @@
 package hive
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"strings"
 )
 
@@ -15,6 +18,12 @@ const (
 	ErrInvalidDataType     = "INVALID_DATA_TYPE"
 	ErrNodeNotFound        = "NODE_NOT_FOUND"
 	ErrInvalidUpdate       = "INVALID_UPDATE"
+	ErrInvalidDuration     = "INVALID_DURATION"
+	ErrInvalidSchedule     = "INVALID_SCHEDULE"
+	ErrInvalidHolidayMode  = "INVALID_HOLIDAY_MODE"
+	ErrClosed              = "CLOSED"
+	ErrUnexpectedResponse  = "UNEXPECTED_RESPONSE"
+	ErrOutOfRange          = "OUT_OF_RANGE"
 )
 
 // Error codes from Hive API
@@ -24,6 +33,28 @@ const (
 	ErrNotAuthorized      = "NOT_AUTHORIZED"
 )
 
+// Sentinel errors for each error code, matched by errors.Is against any
+// Error with the same code, such as errors.Is(err, ErrorNotAuthorized).
+var (
+	ErrorInternal             = &Error{Code: ErrInternal, Message: "internal error"}
+	ErrorInvalidJSON          = &Error{Code: ErrInvalidJSON, Message: "invalid json"}
+	ErrorInvalidLoginResponse = &Error{Code: ErrInvalidLoginRespose, Message: "invalid login response"}
+	ErrorInvalidNodeType      = &Error{Code: ErrInvalidNodeType, Message: "invalid node type"}
+	ErrorInvalidNodeJSON      = &Error{Code: ErrInvalidNodeJSON, Message: "invalid node json"}
+	ErrorInvalidDataType      = &Error{Code: ErrInvalidDataType, Message: "invalid data type"}
+	ErrorNodeNotFound         = &Error{Code: ErrNodeNotFound, Message: "node not found"}
+	ErrorInvalidUpdate        = &Error{Code: ErrInvalidUpdate, Message: "invalid update"}
+	ErrorInvalidDuration      = &Error{Code: ErrInvalidDuration, Message: "invalid duration"}
+	ErrorInvalidSchedule      = &Error{Code: ErrInvalidSchedule, Message: "invalid schedule"}
+	ErrorInvalidHolidayMode   = &Error{Code: ErrInvalidHolidayMode, Message: "invalid holiday mode"}
+	ErrorClosed               = &Error{Code: ErrClosed, Message: "closed"}
+	ErrorUnexpectedResponse   = &Error{Code: ErrUnexpectedResponse, Message: "unexpected response"}
+	ErrorOutOfRange           = &Error{Code: ErrOutOfRange, Message: "out of range"}
+	ErrorMissingParameter     = &Error{Code: ErrMissingParameter, Message: "missing parameter"}
+	ErrorInvalidCredentials   = &Error{Code: ErrInvalidCredentials, Message: "invalid username or password"}
+	ErrorNotAuthorized        = &Error{Code: ErrNotAuthorized, Message: "not authorized"}
+)
+
 // Error defines a standard application error.
 type Error struct {
 	// Machine-readable error code.
@@ -32,11 +63,24 @@ type Error struct {
 	// Human-readable message.
 	Message string
 
+	// HTTP status code and request path of a failed API response.
+	StatusCode int
+	Path       string
+
+	// Every error reported by the API, the first provides Code.
+	Errors []APIError
+
 	// Logical operation and nested error.
 	Op  string
 	Err error
 }
 
+// APIError is an error reported in a Hive API response
+type APIError struct {
+	Code  string `json:"code"`
+	Title string `json:"title"`
+}
+
 // Error returns the string representation of the error message.
 func (e *Error) Error() string {
 	var buf strings.Builder
@@ -61,13 +105,49 @@ func (e *Error) Error() string {
 	return buf.String()
 }
 
+// Unwrap returns the nested error, if any.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error, or any error reported by the API along
+// with it, has the code of target. Only the Code of target is compared
+// when it is an *Error, so errors.Is(err, ErrorNotAuthorized) reports
+// whether err carries that code anywhere in its chain.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok || t.Code == "" {
+		return false
+	}
+
+	if e.Code == t.Code {
+		return true
+	}
+
+	for _, ae := range e.Errors {
+		if ae.Code == t.Code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasCode reports whether err, or any error in its chain, has the code.
+func HasCode(err error, code string) bool {
+	return errors.Is(err, &Error{Code: code})
+}
+
 // ErrorCode returns the code of the root error, if available. Otherwise returns ErrInternal.
 func ErrorCode(err error) string {
+	var e *Error
 	if err == nil {
 		return ""
-	} else if e, ok := err.(*Error); ok && e.Code != "" {
+	} else if !errors.As(err, &e) {
+		return ErrInternal
+	} else if e.Code != "" {
 		return e.Code
-	} else if ok && e.Err != nil {
+	} else if e.Err != nil {
 		return ErrorCode(e.Err)
 	}
 
@@ -77,13 +157,67 @@ func ErrorCode(err error) string {
 // ErrorMessage returns the human-readable message of the error, if available.
 // Otherwise returns a generic error message.
 func ErrorMessage(err error) string {
+	var e *Error
 	if err == nil {
 		return ""
-	} else if e, ok := err.(*Error); ok && e.Message != "" {
+	} else if !errors.As(err, &e) {
+		return "internal error has occurred"
+	} else if e.Message != "" {
 		return e.Message
-	} else if ok && e.Err != nil {
+	} else if e.Err != nil {
 		return ErrorMessage(e.Err)
 	}
 
 	return "internal error has occurred"
 }
+
+// StatusCode returns the HTTP status code of the API response which
+// caused err, or 0 if err was not caused by an API response.
+func StatusCode(err error) int {
+	var e *Error
+	for errors.As(err, &e) {
+		if e.StatusCode != 0 {
+			return e.StatusCode
+		}
+
+		err = e.Err
+	}
+
+	return 0
+}
+
+// IsTemporary reports whether err is likely to be resolved by retrying
+// the operation later, such as a network timeout or the API being
+// overloaded or unavailable.
+func IsTemporary(err error) bool {
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return true
+	}
+
+	switch StatusCode(err) {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// IsAuth reports whether err was caused by invalid credentials or an
+// unauthorized session, which will not be resolved without intervention.
+func IsAuth(err error) bool {
+	if errors.Is(err, ErrorInvalidCredentials) || errors.Is(err, ErrorNotAuthorized) {
+		return true
+	}
+
+	switch StatusCode(err) {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return true
+	}
+
+	return false
+}
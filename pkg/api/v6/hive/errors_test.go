@@ -2,6 +2,8 @@ package hive
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
 	"testing"
 )
 
@@ -84,3 +86,115 @@ func TestErrorMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorSentinels(t *testing.T) {
+	tests := []struct {
+		sentinel *Error
+		code     string
+	}{
+		{ErrorInternal, ErrInternal},
+		{ErrorInvalidJSON, ErrInvalidJSON},
+		{ErrorInvalidLoginResponse, ErrInvalidLoginRespose},
+		{ErrorInvalidNodeType, ErrInvalidNodeType},
+		{ErrorInvalidNodeJSON, ErrInvalidNodeJSON},
+		{ErrorInvalidDataType, ErrInvalidDataType},
+		{ErrorNodeNotFound, ErrNodeNotFound},
+		{ErrorInvalidUpdate, ErrInvalidUpdate},
+		{ErrorInvalidDuration, ErrInvalidDuration},
+		{ErrorInvalidSchedule, ErrInvalidSchedule},
+		{ErrorInvalidHolidayMode, ErrInvalidHolidayMode},
+		{ErrorClosed, ErrClosed},
+		{ErrorUnexpectedResponse, ErrUnexpectedResponse},
+		{ErrorOutOfRange, ErrOutOfRange},
+		{ErrorMissingParameter, ErrMissingParameter},
+		{ErrorInvalidCredentials, ErrInvalidCredentials},
+		{ErrorNotAuthorized, ErrNotAuthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if tt.sentinel.Code != tt.code {
+				t.Errorf("sentinel Code = %v, want %v", tt.sentinel.Code, tt.code)
+			}
+
+			err := fmt.Errorf("wrapped: %w", &Error{Op: "testing", Err: &Error{Code: tt.code, Message: "failed"}})
+			if !errors.Is(err, tt.sentinel) {
+				t.Errorf("errors.Is(%v, sentinel) = false, want true", err)
+			}
+
+			if errors.Is(&Error{Code: "OTHER"}, tt.sentinel) {
+				t.Errorf("errors.Is(OTHER, sentinel) = true, want false")
+			}
+		})
+	}
+}
+
+func TestError_Is(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &Error{Op: "home: nodes", Err: &Error{Code: ErrNotAuthorized}})
+
+	if !errors.Is(err, ErrorNotAuthorized) {
+		t.Errorf("errors.Is(%v, ErrorNotAuthorized) = false, want true", err)
+	}
+
+	if errors.Is(err, ErrorInvalidCredentials) {
+		t.Errorf("errors.Is(%v, ErrorInvalidCredentials) = true, want false", err)
+	}
+
+	if !errors.Is(err, &Error{Code: ErrNotAuthorized}) {
+		t.Errorf("errors.Is(%v, &Error{Code: ErrNotAuthorized}) = false, want true", err)
+	}
+
+	if errors.Is(err, &Error{}) {
+		t.Errorf("errors.Is(%v, &Error{}) = true, want false", err)
+	}
+
+	api := &Error{Code: ErrMissingParameter, Errors: []APIError{{Code: ErrMissingParameter}, {Code: ErrNotAuthorized}}}
+	if !HasCode(api, ErrNotAuthorized) {
+		t.Errorf("HasCode(%v, ErrNotAuthorized) = false, want true", api)
+	}
+
+	if got := ErrorCode(err); got != ErrNotAuthorized {
+		t.Errorf("ErrorCode() = %v, want %v", got, ErrNotAuthorized)
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestErrorHelpers(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		status    int
+		temporary bool
+		auth      bool
+	}{
+		{"Nil", nil, 0, false, false},
+		{"Standard", errors.New("example"), 0, false, false},
+		{"Timeout", &Error{Op: "home: request", Err: timeoutError{}}, 0, true, false},
+		{"Unavailable", &Error{Op: "home: nodes", Err: &Error{StatusCode: http.StatusServiceUnavailable}},
+			http.StatusServiceUnavailable, true, false},
+		{"BadRequest", &Error{Code: ErrMissingParameter, StatusCode: http.StatusBadRequest},
+			http.StatusBadRequest, false, false},
+		{"InvalidCredentials", &Error{Code: ErrInvalidCredentials, StatusCode: http.StatusBadRequest},
+			http.StatusBadRequest, false, true},
+		{"Forbidden", &Error{StatusCode: http.StatusForbidden}, http.StatusForbidden, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StatusCode(tt.err); got != tt.status {
+				t.Errorf("StatusCode() = %v, want %v", got, tt.status)
+			}
+
+			if got := IsTemporary(tt.err); got != tt.temporary {
+				t.Errorf("IsTemporary() = %v, want %v", got, tt.temporary)
+			}
+
+			if got := IsAuth(tt.err); got != tt.auth {
+				t.Errorf("IsAuth() = %v, want %v", got, tt.auth)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package hive
+
+import "time"
+
+// lastSeen returns when the node was last heard from. Nodes without a
+// lastSeen value fall back to their most recently received attribute.
+func (n *node) lastSeen() time.Time {
+	if n.LastSeen != 0 {
+		return millisTime(n.LastSeen)
+	}
+
+	var last int64
+	for _, attr := range n.Attributes {
+		if attr.ReportReceivedTime > last {
+			last = attr.ReportReceivedTime
+		}
+	}
+
+	return millisTime(last)
+}
+
+// online reports whether the node is present and, when threshold is
+// positive, has been seen within threshold of now.
+func (n *node) online(threshold time.Duration, now time.Time) bool {
+	if presence, ok := n.attr("presence").ReportedValueString(); ok && presence != "PRESENT" {
+		return false
+	}
+
+	if threshold <= 0 {
+		return true
+	}
+
+	last := n.lastSeen()
+	return !last.IsZero() && now.Sub(last) <= threshold
+}
+
+// attribute returns the named attribute of the node
+func (n *node) attribute(name string) (*Attribute, bool) {
+	attr, ok := n.Attributes[name]
+	if !ok {
+		return nil, false
+	}
+
+	return &Attribute{Name: name, attr: attr}, true
+}
+
+// LastSeen returns when the Thermostat was last heard from
+func (t *Thermostat) LastSeen() time.Time {
+	return t.current().lastSeen()
+}
+
+// IsOnline reports whether the Thermostat is present and has been seen
+// within threshold, a threshold of zero only checks its presence.
+func (t *Thermostat) IsOnline(threshold time.Duration) bool {
+	return t.current().online(threshold, t.home.clock().Now())
+}
+
+// Attribute returns the named attribute of the Thermostat, giving access
+// to when each value was reported.
+func (t *Thermostat) Attribute(name string) (*Attribute, bool) {
+	return t.current().attribute(name)
+}
+
+// LastSeen returns when the Controller was last heard from
+func (c *Controller) LastSeen() time.Time {
+	return c.current().lastSeen()
+}
+
+// IsOnline reports whether the Controller is present and has been seen
+// within threshold, a threshold of zero only checks its presence.
+func (c *Controller) IsOnline(threshold time.Duration) bool {
+	return c.current().online(threshold, c.home.clock().Now())
+}
+
+// Attribute returns the named attribute of the Controller
+func (c *Controller) Attribute(name string) (*Attribute, bool) {
+	return c.current().attribute(name)
+}
+
+// LastSeen returns when the HotWater was last heard from
+func (hw *HotWater) LastSeen() time.Time {
+	return hw.current().lastSeen()
+}
+
+// IsOnline reports whether the HotWater is present and has been seen
+// within threshold, a threshold of zero only checks its presence.
+func (hw *HotWater) IsOnline(threshold time.Duration) bool {
+	return hw.current().online(threshold, hw.home.clock().Now())
+}
+
+// Attribute returns the named attribute of the HotWater
+func (hw *HotWater) Attribute(name string) (*Attribute, bool) {
+	return hw.current().attribute(name)
+}
+
+// IsOnline reports whether the Node is present and has been seen within
+// threshold, a threshold of zero only checks its presence.
+func (n *Node) IsOnline(threshold time.Duration) bool {
+	return n.current().online(threshold, n.home.clock().Now())
+}
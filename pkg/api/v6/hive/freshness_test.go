@@ -0,0 +1,102 @@
+package hive
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func Test_node_online(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	ms := func(d time.Duration) int64 {
+		return now.Add(-d).UnixNano() / int64(time.Millisecond)
+	}
+
+	tests := []struct {
+		name      string
+		presence  interface{}
+		lastSeen  int64
+		received  int64
+		threshold time.Duration
+		want      bool
+	}{
+		{"Present", "PRESENT", ms(time.Minute), 0, 10 * time.Minute, true},
+		{"Absent", "ABSENT", ms(time.Minute), 0, 10 * time.Minute, false},
+		{"Stale", "PRESENT", ms(time.Hour), 0, 10 * time.Minute, false},
+		{"StaleNoThreshold", "PRESENT", ms(time.Hour), 0, 0, true},
+		{"AbsentNoThreshold", "ABSENT", ms(time.Minute), 0, 0, false},
+		{"NoPresence", nil, ms(time.Minute), 0, 10 * time.Minute, true},
+		{"AttributeFallback", nil, 0, ms(time.Minute), 10 * time.Minute, true},
+		{"AttributeFallbackStale", nil, 0, ms(time.Hour), 10 * time.Minute, false},
+		{"NeverSeen", nil, 0, 0, 10 * time.Minute, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &node{
+				LastSeen: tt.lastSeen,
+				Attributes: nodeAttributes{
+					"temperature": {ReportedValue: 19.5, ReportReceivedTime: tt.received},
+				},
+			}
+
+			if tt.presence != nil {
+				n.Attributes["presence"] = &nodeAttribute{ReportedValue: tt.presence}
+			}
+
+			if got := n.online(tt.threshold, now); got != tt.want {
+				t.Errorf("node.online() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThermostat_LastSeen(t *testing.T) {
+	var n node
+	if err := json.Unmarshal([]byte(`{
+		"id": "thermostat",
+		"lastSeen": 1539205419366,
+		"attributes": {
+			"presence": {"reportedValue": "PRESENT"},
+			"temperature": {
+				"reportedValue": 19.5,
+				"reportReceivedTime": 1539205419366,
+				"reportChangedTime": 1528575087449
+			}
+		}
+	}`), &n); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	ts := &Thermostat{ID: n.ID, node: &n}
+
+	if got, want := ts.LastSeen(), time.Unix(1539205419, 366*int64(time.Millisecond)); !got.Equal(want) {
+		t.Errorf("Thermostat.LastSeen() = %v, want %v", got, want)
+	}
+
+	if ts.IsOnline(time.Hour) {
+		t.Errorf("Thermostat.IsOnline() = true, want false for a node last seen in 2018")
+	}
+
+	if !ts.IsOnline(0) {
+		t.Errorf("Thermostat.IsOnline(0) = false, want true for a present node")
+	}
+
+	ts.home = &Home{clk: &fakeClock{now: time.Unix(1539205419, 0).Add(30 * time.Minute)}}
+
+	if !ts.IsOnline(time.Hour) {
+		t.Errorf("Thermostat.IsOnline() = false, want true for a node seen 30 minutes before the Home clock")
+	}
+
+	attr, ok := ts.Attribute("temperature")
+	if !ok {
+		t.Fatalf("Thermostat.Attribute() ok = false, want true")
+	}
+
+	if got, want := attr.ReportChanged(), time.Unix(1528575087, 449*int64(time.Millisecond)); !got.Equal(want) {
+		t.Errorf("Attribute.ReportChanged() = %v, want %v", got, want)
+	}
+
+	if _, ok := ts.Attribute("missing"); ok {
+		t.Errorf("Thermostat.Attribute() ok = true, want false")
+	}
+}
@@ -0,0 +1,41 @@
+package hivetest
+
+// Node types served by the fake
+const (
+	NodeTypeThermostat   = "http://alertme.com/schema/json/node.class.thermostat.json#"
+	NodeTypeThermostatUI = "http://alertme.com/schema/json/node.class.thermostatui.json#"
+)
+
+// Thermostat returns a heating thermostat node in manual mode measuring
+// temp and targeting target.
+func Thermostat(id, name string, temp, target float64) *Node {
+	return &Node{
+		ID:   id,
+		Name: name,
+		Attributes: map[string]*Attribute{
+			"nodeType":                {ReportedValue: NodeTypeThermostat},
+			"presence":                {ReportedValue: "PRESENT"},
+			"temperature":             {ReportedValue: temp},
+			"targetHeatTemperature":   {ReportedValue: target},
+			"minHeatTemperature":      {ReportedValue: 5.0},
+			"maxHeatTemperature":      {ReportedValue: 32.0},
+			"frostProtectTemperature": {ReportedValue: 7.0},
+			"activeHeatCoolMode":      {ReportedValue: "HEAT"},
+			"activeScheduleLock":      {ReportedValue: true},
+			"stateHeatingRelay":       {ReportedValue: "OFF"},
+		},
+	}
+}
+
+// Controller returns a thermostat UI node with the given battery level
+func Controller(id, name string, battery float64) *Node {
+	return &Node{
+		ID:   id,
+		Name: name,
+		Attributes: map[string]*Attribute{
+			"nodeType":     {ReportedValue: NodeTypeThermostatUI},
+			"presence":     {ReportedValue: "PRESENT"},
+			"batteryLevel": {ReportedValue: battery},
+		},
+	}
+}
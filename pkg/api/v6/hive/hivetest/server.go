@@ -0,0 +1,433 @@
+// Package hivetest provides an in-memory fake of the Hive v6 Omnia API
+// for testing clients of package hive.
+//
+// The fake is stateful, logins create sessions which can be expired,
+// nodes can be listed and fetched, and PUT updates record target values
+// and move them into the reported values as a real device would.
+package hivetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const contentType = "application/vnd.alertme.zoo-6.1+json;charset=UTF-8"
+
+// Attribute is a node attribute as served by the fake
+type Attribute struct {
+	ReportedValue      interface{} `json:"reportedValue,omitempty"`
+	DisplayValue       interface{} `json:"displayValue,omitempty"`
+	TargetValue        interface{} `json:"targetValue,omitempty"`
+	ReportReceivedTime int64       `json:"reportReceivedTime,omitempty"`
+	ReportChangedTime  int64       `json:"reportChangedTime,omitempty"`
+}
+
+// Node is a device as served by the fake, its href is derived from the
+// URL of the Server.
+type Node struct {
+	ID            string                `json:"id"`
+	Href          string                `json:"href"`
+	Name          string                `json:"name,omitempty"`
+	ParentNodeID  string                `json:"parentNodeId,omitempty"`
+	LastSeen      int64                 `json:"lastSeen,omitempty"`
+	Attributes    map[string]*Attribute `json:"attributes"`
+	Relationships json.RawMessage       `json:"relationships,omitempty"`
+}
+
+// Failure is an error response injected into the fake
+type Failure struct {
+	Status int
+	Code   string
+	Title  string
+
+	// RetryAfter is sent as the Retry-After header when not empty
+	RetryAfter string
+}
+
+// Server is a fake Hive API server
+type Server struct {
+	*httptest.Server
+
+	// Username and Password are the credentials accepted by the Server
+	Username string
+	Password string
+
+	mu        sync.Mutex
+	sessions  map[string]bool
+	nodes     map[string]*Node
+	latency   time.Duration
+	failures  []func(r *http.Request) *Failure
+	pending   bool
+	noContent bool
+	now       func() time.Time
+	logins    int
+	requests  int
+}
+
+// NewServer starts a fake Hive API accepting the given credentials
+func NewServer(username, password string) *Server {
+	s := &Server{
+		Username: username,
+		Password: password,
+		sessions: make(map[string]bool),
+		nodes:    make(map[string]*Node),
+		now:      time.Now,
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// AddNode adds or replaces a node served by the Server
+func (s *Server) AddNode(n *Node) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n.Attributes == nil {
+		n.Attributes = make(map[string]*Attribute)
+	}
+
+	n.Href = s.URL + "/omnia/nodes/" + n.ID
+	s.nodes[n.ID] = n
+}
+
+// RemoveNode stops the Server serving the node with the given ID
+func (s *Server) RemoveNode(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.nodes, id)
+}
+
+// Report sets the reported value of a node attribute, as if the device
+// had reported a new value.
+func (s *Server) Report(id, attr string, v interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, ok := s.nodes[id]
+	if !ok {
+		return
+	}
+
+	s.report(n, attr, v)
+}
+
+func (s *Server) report(n *Node, name string, v interface{}) {
+	now := s.now().UnixNano() / int64(time.Millisecond)
+
+	a, ok := n.Attributes[name]
+	if !ok {
+		a = &Attribute{}
+		n.Attributes[name] = a
+	}
+
+	if fmt.Sprint(a.ReportedValue) != fmt.Sprint(v) {
+		a.ReportChangedTime = now
+	}
+
+	a.ReportedValue = v
+	a.DisplayValue = v
+	a.ReportReceivedTime = now
+	n.LastSeen = now
+}
+
+// Attribute returns a copy of a node attribute, or nil if it is missing
+func (s *Server) Attribute(id, attr string) *Attribute {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, ok := s.nodes[id]
+	if !ok {
+		return nil
+	}
+
+	a, ok := n.Attributes[attr]
+	if !ok {
+		return nil
+	}
+
+	c := *a
+	return &c
+}
+
+// SetPending controls whether updates are left pending. While pending the
+// target values of PUT updates are recorded but not reported until
+// Settle is called, otherwise they are reported immediately.
+func (s *Server) SetPending(pending bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending = pending
+}
+
+// SetNoContent controls whether PUT updates respond with 204 No Content
+// rather than the updated node.
+func (s *Server) SetNoContent(noContent bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.noContent = noContent
+}
+
+// Settle reports every pending target value, as if each device had
+// applied its requested changes.
+func (s *Server) Settle() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, n := range s.nodes {
+		for name, a := range n.Attributes {
+			if a.TargetValue != nil {
+				s.report(n, name, a.TargetValue)
+			}
+		}
+	}
+}
+
+// SetNow replaces the clock used to timestamp reports
+func (s *Server) SetNow(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.now = now
+}
+
+// SetLatency delays every response by d
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = d
+}
+
+// Fail registers a hook called for each request, returning a non-nil
+// Failure fails the request with it. Hooks are called in the order they
+// are registered.
+func (s *Server) Fail(hook func(r *http.Request) *Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, hook)
+}
+
+// FailNext fails the next n requests with f
+func (s *Server) FailNext(n int, f Failure) {
+	var mu sync.Mutex
+	s.Fail(func(*http.Request) *Failure {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if n <= 0 {
+			return nil
+		}
+
+		n--
+		return &f
+	})
+}
+
+// ExpireSessions invalidates every session, requests with them are
+// rejected as NOT_AUTHORIZED until the client logs in again.
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions = make(map[string]bool)
+}
+
+// Sessions returns the number of active sessions
+func (s *Server) Sessions() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.sessions)
+}
+
+// Logins returns the number of successful logins
+func (s *Server) Logins() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.logins
+}
+
+// Requests returns the number of requests served
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests++
+	latency := s.latency
+	failures := s.failures
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", contentType)
+
+	for _, hook := range failures {
+		if f := hook(r); f != nil {
+			if f.RetryAfter != "" {
+				w.Header().Set("Retry-After", f.RetryAfter)
+			}
+
+			writeError(w, f.Status, f.Code, f.Title)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	const sessionsPath = "/omnia/auth/sessions"
+	const nodesPath = "/omnia/nodes"
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == sessionsPath:
+		s.login(w, r)
+		return
+	case !s.sessions[r.Header.Get("X-Omnia-Access-Token")]:
+		writeError(w, http.StatusUnauthorized, "NOT_AUTHORIZED", "Not authorized")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, sessionsPath+"/"):
+		delete(s.sessions, strings.TrimPrefix(r.URL.Path, sessionsPath+"/"))
+		writeJSON(w, struct{}{})
+	case r.Method == http.MethodGet && r.URL.Path == nodesPath:
+		writeJSON(w, map[string]interface{}{"nodes": s.sortedNodes()})
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, nodesPath+"/"):
+		n, ok := s.nodes[strings.TrimPrefix(r.URL.Path, nodesPath+"/")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "Node not found")
+			return
+		}
+
+		writeJSON(w, map[string]interface{}{"nodes": []*Node{n}})
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, nodesPath+"/"):
+		s.update(w, r, strings.TrimPrefix(r.URL.Path, nodesPath+"/"))
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Unknown path")
+	}
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Sessions []struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"sessions"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Sessions) != 1 {
+		writeError(w, http.StatusBadRequest, "MISSING_PARAMETER", "Username and password not specified")
+		return
+	}
+
+	if req.Sessions[0].Username != s.Username || req.Sessions[0].Password != s.Password {
+		writeError(w, http.StatusBadRequest, "USERNAME_PASSWORD_ERROR", "Username or password not specified or invalid")
+		return
+	}
+
+	s.logins++
+	id := fmt.Sprintf("hivetest-session-%d", s.logins)
+	s.sessions[id] = true
+
+	writeJSON(w, map[string]interface{}{
+		"sessions": []map[string]interface{}{{
+			"id":                        id,
+			"username":                  s.Username,
+			"userId":                    "hivetest-user",
+			"extCustomerLevel":          1,
+			"latestSupportedApiVersion": "6",
+			"sessionId":                 id,
+		}},
+	})
+}
+
+func (s *Server) update(w http.ResponseWriter, r *http.Request, id string) {
+	n, ok := s.nodes[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Node not found")
+		return
+	}
+
+	var req struct {
+		Nodes []struct {
+			Attributes map[string]*Attribute `json:"attributes"`
+		} `json:"nodes"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Nodes) != 1 {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAMETER", "Node configuration error")
+		return
+	}
+
+	for name, a := range req.Nodes[0].Attributes {
+		if a == nil || a.TargetValue == nil {
+			continue
+		}
+
+		if _, ok := n.Attributes[name]; !ok {
+			n.Attributes[name] = &Attribute{}
+		}
+
+		n.Attributes[name].TargetValue = a.TargetValue
+
+		if !s.pending {
+			s.report(n, name, a.TargetValue)
+		}
+	}
+
+	if s.noContent {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{"nodes": []*Node{n}})
+}
+
+func (s *Server) sortedNodes() []*Node {
+	nodes := make([]*Node, 0, len(s.nodes))
+	for _, n := range s.nodes {
+		nodes = append(nodes, n)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	return nodes
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, title string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []map[string]interface{}{{
+			"code":  code,
+			"title": title,
+			"links": []interface{}{},
+		}},
+	})
+}
@@ -0,0 +1,199 @@
+package hivetest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/geoffgarside/homekit-hive/pkg/api/v6/hive"
+	"github.com/geoffgarside/homekit-hive/pkg/api/v6/hive/hivetest"
+)
+
+func connect(t *testing.T, srv *hivetest.Server, opts ...hive.Option) *hive.Home {
+	t.Helper()
+
+	opts = append([]hive.Option{
+		hive.WithCredentials("username", "password"),
+		hive.WithHTTPClient(srv.Client()),
+		hive.WithURL(srv.URL),
+	}, opts...)
+
+	home, err := hive.Connect(opts...)
+	if err != nil {
+		t.Fatalf("hive.Connect() error = %v, want nil", err)
+	}
+
+	return home
+}
+
+func TestServer_Login(t *testing.T) {
+	srv := hivetest.NewServer("username", "password")
+	defer srv.Close()
+
+	_, err := hive.Connect(
+		hive.WithCredentials("username", "wrong"),
+		hive.WithHTTPClient(srv.Client()),
+		hive.WithURL(srv.URL),
+	)
+
+	if !errors.Is(err, hive.ErrorInvalidCredentials) || !hive.IsAuth(err) {
+		t.Errorf("hive.Connect() error = %v, want %v", err, hive.ErrInvalidCredentials)
+	}
+
+	if got := hive.StatusCode(err); got != http.StatusBadRequest {
+		t.Errorf("hive.StatusCode() = %v, want %v", got, http.StatusBadRequest)
+	}
+
+	connect(t, srv)
+
+	if got := srv.Sessions(); got != 1 {
+		t.Errorf("Server.Sessions() = %v, want 1", got)
+	}
+}
+
+func TestServer_SetTarget(t *testing.T) {
+	srv := hivetest.NewServer("username", "password")
+	defer srv.Close()
+
+	srv.AddNode(hivetest.Thermostat("t1", "Heating", 19.5, 20))
+	srv.AddNode(hivetest.Controller("c1", "Thermostat", 80))
+
+	home := connect(t, srv)
+
+	thermostats, err := home.Thermostats()
+	if err != nil || len(thermostats) != 1 {
+		t.Fatalf("Home.Thermostats() = %v, %v, want 1 thermostat", thermostats, err)
+	}
+
+	if err := thermostats[0].SetTarget(21); err != nil {
+		t.Fatalf("Thermostat.SetTarget() error = %v, want nil", err)
+	}
+
+	if got, _ := thermostats[0].Target(); got != 21 {
+		t.Errorf("Thermostat.Target() = %v, want 21", got)
+	}
+
+	if got := srv.Attribute("t1", "targetHeatTemperature"); got.ReportedValue != 21.0 || got.TargetValue != 21.0 {
+		t.Errorf("Server.Attribute() = %+v, want reported and target 21", got)
+	}
+}
+
+func TestServer_SetNoContent(t *testing.T) {
+	srv := hivetest.NewServer("username", "password")
+	defer srv.Close()
+
+	srv.AddNode(hivetest.Thermostat("t1", "Heating", 19.5, 20))
+	srv.SetNoContent(true)
+
+	home := connect(t, srv)
+
+	thermostats, err := home.Thermostats()
+	if err != nil || len(thermostats) != 1 {
+		t.Fatalf("Home.Thermostats() = %v, %v, want 1 thermostat", thermostats, err)
+	}
+
+	if err := thermostats[0].SetTarget(21); err != nil {
+		t.Fatalf("Thermostat.SetTarget() error = %v, want nil", err)
+	}
+
+	if got, _ := thermostats[0].Target(); got != 21 {
+		t.Errorf("Thermostat.Target() = %v, want 21", got)
+	}
+
+	if got := thermostats[0].PendingChanges(); len(got) != 0 {
+		t.Errorf("Thermostat.PendingChanges() = %v, want none once reported", got)
+	}
+}
+
+func TestServer_SetPending(t *testing.T) {
+	srv := hivetest.NewServer("username", "password")
+	defer srv.Close()
+
+	srv.AddNode(hivetest.Thermostat("t1", "Heating", 19.5, 20))
+	srv.SetPending(true)
+
+	home := connect(t, srv)
+
+	thermostats, err := home.Thermostats()
+	if err != nil || len(thermostats) != 1 {
+		t.Fatalf("Home.Thermostats() = %v, %v, want 1 thermostat", thermostats, err)
+	}
+
+	if err := thermostats[0].SetTarget(21); err != nil {
+		t.Fatalf("Thermostat.SetTarget() error = %v, want nil", err)
+	}
+
+	if got, _ := thermostats[0].Target(); got != 20 {
+		t.Errorf("Thermostat.Target() = %v, want 20 while pending", got)
+	}
+
+	srv.Settle()
+
+	if err := home.Refresh(); err != nil {
+		t.Fatalf("Home.Refresh() error = %v, want nil", err)
+	}
+
+	if got, _ := thermostats[0].Target(); got != 21 {
+		t.Errorf("Thermostat.Target() = %v, want 21 once settled", got)
+	}
+}
+
+func TestServer_ExpireSessions(t *testing.T) {
+	srv := hivetest.NewServer("username", "password")
+	defer srv.Close()
+
+	srv.AddNode(hivetest.Thermostat("t1", "Heating", 19.5, 20))
+
+	home := connect(t, srv)
+	srv.ExpireSessions()
+
+	if _, err := home.Thermostats(); err != nil {
+		t.Fatalf("Home.Thermostats() error = %v, want nil", err)
+	}
+
+	if got := srv.Logins(); got != 2 {
+		t.Errorf("Server.Logins() = %v, want 2", got)
+	}
+}
+
+func TestServer_FailNext(t *testing.T) {
+	srv := hivetest.NewServer("username", "password")
+	defer srv.Close()
+
+	srv.AddNode(hivetest.Thermostat("t1", "Heating", 19.5, 20))
+
+	home := connect(t, srv, hive.WithRetryPolicy(hive.RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    time.Millisecond,
+	}))
+
+	srv.FailNext(2, hivetest.Failure{Status: http.StatusServiceUnavailable, Code: "UNAVAILABLE"})
+
+	if _, err := home.Thermostats(); err != nil {
+		t.Fatalf("Home.Thermostats() error = %v, want nil", err)
+	}
+
+	srv.FailNext(3, hivetest.Failure{Status: http.StatusServiceUnavailable, Code: "UNAVAILABLE"})
+
+	if _, err := home.Thermostats(); hive.ErrorCode(err) != "UNAVAILABLE" {
+		t.Errorf("Home.Thermostats() error = %v, want UNAVAILABLE", err)
+	}
+}
+
+func TestServer_SetLatency(t *testing.T) {
+	srv := hivetest.NewServer("username", "password")
+	defer srv.Close()
+
+	home := connect(t, srv)
+	srv.SetLatency(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := home.ThermostatsContext(ctx); err == nil {
+		t.Errorf("Home.ThermostatsContext() error = %v, want != nil", err)
+	}
+}
@@ -0,0 +1,140 @@
+package hive
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// HolidayMode is the holiday mode configuration of the Home, while
+// enabled the heating is held at Target between Start and End.
+type HolidayMode struct {
+	Enabled bool      `json:"enabled"`
+	Start   time.Time `json:"startDateTime"`
+	End     time.Time `json:"endDateTime"`
+	Target  float64   `json:"targetHeatTemperature"`
+}
+
+// HolidayMode returns the holiday mode configuration of the Home
+func (home *Home) HolidayMode() (*HolidayMode, error) {
+	return home.HolidayModeContext(context.Background())
+}
+
+// HolidayModeContext returns the holiday mode configuration of the Home
+// using the provided context.
+func (home *Home) HolidayModeContext(ctx context.Context) (*HolidayMode, error) {
+	thermostats, err := home.ThermostatsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range thermostats {
+		attr := t.current().attr("holidayMode")
+		if attr.ReportedValue == nil {
+			continue
+		}
+
+		var hm HolidayMode
+		if err := attr.decodeReportedValue(&hm); err != nil {
+			return nil, &Error{Op: "home: holiday mode: decode", Code: ErrInvalidJSON, Err: err}
+		}
+
+		return &hm, nil
+	}
+
+	return nil, &Error{
+		Op:      "home: holiday mode",
+		Code:    ErrNodeNotFound,
+		Message: "no thermostat supports holiday mode",
+	}
+}
+
+// SetHolidayMode holds the heating of every thermostat in the Home at
+// temp between start and end.
+func (home *Home) SetHolidayMode(start, end time.Time, temp float64) error {
+	return home.SetHolidayModeContext(context.Background(), start, end, temp)
+}
+
+// SetHolidayModeContext holds the heating of every thermostat in the
+// Home at temp between start and end using the provided context.
+func (home *Home) SetHolidayModeContext(ctx context.Context, start, end time.Time, temp float64) error {
+	const op = "home: set holiday mode"
+
+	if !end.After(start) {
+		return &Error{
+			Op:      op,
+			Code:    ErrInvalidHolidayMode,
+			Message: "holiday must end after it starts",
+		}
+	}
+
+	if !end.After(home.clock().Now()) {
+		return &Error{
+			Op:      op,
+			Code:    ErrInvalidHolidayMode,
+			Message: "holiday must end in the future",
+		}
+	}
+
+	thermostats, err := home.ThermostatsContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range thermostats {
+		if temp < t.Minimum() || temp > t.Maximum() {
+			return &Error{
+				Op:      op,
+				Code:    ErrInvalidHolidayMode,
+				Message: fmt.Sprintf("holiday temperature %v outside of %v to %v", temp, t.Minimum(), t.Maximum()),
+			}
+		}
+	}
+
+	return home.setHolidayMode(ctx, op, thermostats, &HolidayMode{
+		Enabled: true,
+		Start:   start,
+		End:     end,
+		Target:  temp,
+	})
+}
+
+// CancelHolidayMode ends holiday mode on every thermostat in the Home
+func (home *Home) CancelHolidayMode() error {
+	return home.CancelHolidayModeContext(context.Background())
+}
+
+// CancelHolidayModeContext ends holiday mode on every thermostat in the
+// Home using the provided context.
+func (home *Home) CancelHolidayModeContext(ctx context.Context) error {
+	thermostats, err := home.ThermostatsContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return home.setHolidayMode(ctx, "home: cancel holiday mode", thermostats, map[string]interface{}{
+		"enabled": false,
+	})
+}
+
+// setHolidayMode sets the holiday mode attribute on every thermostat,
+// returning an ErrNodeNotFound error if there are none.
+func (home *Home) setHolidayMode(ctx context.Context, op string, thermostats []*Thermostat, value interface{}) error {
+	if len(thermostats) == 0 {
+		return &Error{
+			Op:      op,
+			Code:    ErrNodeNotFound,
+			Message: "no thermostat supports holiday mode",
+		}
+	}
+
+	for _, t := range thermostats {
+		if err := home.setAttributes(ctx, op, t, nodeAttributes{
+			"holidayMode": {TargetValue: value},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package hive_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/geoffgarside/homekit-hive/pkg/api/v6/hive"
+	"github.com/geoffgarside/homekit-hive/pkg/api/v6/hive/hivetest"
+)
+
+func TestHomeHolidayMode(t *testing.T) {
+	const nodeID = "fe49e95e-c8cc-47cc-b38f-ec0c06361e13"
+
+	holiday := json.RawMessage(`{"enabled":false}`)
+	var puts int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.alertme.zoo-6.1+json;charset=UTF-8")
+
+		writeNode := func() {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{
+				"nodes": [{
+					"id": %q,
+					"href": "https://api-prod.bgchprod.info/omnia/nodes/%s",
+					"name": "Receiver 1",
+					"attributes": {
+						"temperature": {
+							"reportedValue": 17.67,
+							"displayValue": 17.67
+						},
+						"minHeatTemperature": {
+							"reportedValue": 5.0,
+							"displayValue": 5.0
+						},
+						"maxHeatTemperature": {
+							"reportedValue": 32.0,
+							"displayValue": 32.0
+						},
+						"holidayMode": {
+							"reportedValue": %s
+						},
+						"nodeType": {
+							"reportedValue": "http://alertme.com/schema/json/node.class.thermostat.json#",
+							"displayValue": "http://alertme.com/schema/json/node.class.thermostat.json#"
+						}
+					}
+				}]
+			}`, nodeID, nodeID, holiday)
+		}
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/omnia/auth/sessions":
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{
+				"sessions":[{
+					"id":"4wdz82NrUmdYCuuNz3wzofWGymjRWigL",
+					"username":"username",
+					"userId":"b3a1835b-d27a-4ce9-b095-830fe9f0e398",
+					"extCustomerLevel":1,
+					"latestSupportedApiVersion":"6",
+					"sessionId":"4wdz82NrUmdYCuuNz3wzofWGymjRWigL"
+				}]
+			}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/omnia/nodes":
+			writeNode()
+		case r.Method == http.MethodPut && r.URL.Path == "/omnia/nodes/"+nodeID:
+			var req struct {
+				Nodes []struct {
+					Attributes struct {
+						HolidayMode struct {
+							TargetValue json.RawMessage `json:"targetValue"`
+						} `json:"holidayMode"`
+					} `json:"attributes"`
+				} `json:"nodes"`
+			}
+
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Nodes) != 1 {
+				http.Error(w,
+					`{"errors": [{"code": "INVALID_PARAMETER","title": "Node configuration error", "links": []}]}`,
+					http.StatusBadRequest)
+				return
+			}
+
+			puts++
+			holiday = req.Nodes[0].Attributes.HolidayMode.TargetValue
+			writeNode()
+		default:
+			http.Error(w, "unknown path", http.StatusNotFound)
+		}
+	}))
+
+	defer srv.Close()
+
+	home, err := hive.Connect(
+		hive.WithCredentials("username", "password"),
+		hive.WithHTTPClient(srv.Client()),
+		hive.WithURL(srv.URL),
+	)
+	if err != nil {
+		t.Fatalf("hive.Connect() error = %v, want nil", err)
+	}
+
+	hm, err := home.HolidayMode()
+	if err != nil {
+		t.Fatalf("Home.HolidayMode() error = %v, want nil", err)
+	}
+
+	if hm.Enabled {
+		t.Errorf("Home.HolidayMode() enabled = %v, want false", hm.Enabled)
+	}
+
+	start := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	end := start.Add(14 * 24 * time.Hour)
+
+	invalid := []struct {
+		name       string
+		start, end time.Time
+		temp       float64
+	}{
+		{"EndBeforeStart", end, start, 10},
+		{"EndInPast", start.Add(-72 * time.Hour), start.Add(-48 * time.Hour), 10},
+		{"BelowMinimum", start, end, 4},
+		{"AboveMaximum", start, end, 33},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			err := home.SetHolidayMode(tt.start, tt.end, tt.temp)
+			if hive.ErrorCode(err) != hive.ErrInvalidHolidayMode {
+				t.Errorf("Home.SetHolidayMode() error = %v, want %v", err, hive.ErrInvalidHolidayMode)
+			}
+		})
+	}
+
+	if puts != 0 {
+		t.Fatalf("Home.SetHolidayMode() invalid puts = %v, want 0", puts)
+	}
+
+	if err := home.SetHolidayMode(start, end, 10); err != nil {
+		t.Fatalf("Home.SetHolidayMode() error = %v, want nil", err)
+	}
+
+	hm, err = home.HolidayMode()
+	if err != nil {
+		t.Fatalf("Home.HolidayMode() error = %v, want nil", err)
+	}
+
+	want := hive.HolidayMode{Enabled: true, Start: start, End: end, Target: 10}
+	if !hm.Start.Equal(want.Start) || !hm.End.Equal(want.End) || hm.Enabled != want.Enabled || hm.Target != want.Target {
+		t.Errorf("Home.HolidayMode() = %+v, want %+v", *hm, want)
+	}
+
+	if err := home.CancelHolidayMode(); err != nil {
+		t.Fatalf("Home.CancelHolidayMode() error = %v, want nil", err)
+	}
+
+	if hm, _ := home.HolidayMode(); hm == nil || hm.Enabled {
+		t.Errorf("Home.HolidayMode() after cancel = %+v, want disabled", hm)
+	}
+}
+
+func TestHomeHolidayMode_noThermostats(t *testing.T) {
+	srv := hivetest.NewServer("username", "password")
+	defer srv.Close()
+
+	srv.AddNode(hivetest.Controller("c1", "Thermostat", 80))
+
+	home, err := hive.Connect(
+		hive.WithCredentials("username", "password"),
+		hive.WithHTTPClient(srv.Client()),
+		hive.WithURL(srv.URL),
+	)
+	if err != nil {
+		t.Fatalf("hive.Connect() error = %v, want nil", err)
+	}
+
+	if _, err := home.HolidayMode(); hive.ErrorCode(err) != hive.ErrNodeNotFound {
+		t.Errorf("Home.HolidayMode() error = %v, want %v", err, hive.ErrNodeNotFound)
+	}
+
+	start := time.Now().Add(time.Hour)
+	if err := home.SetHolidayMode(start, start.Add(24*time.Hour), 12); hive.ErrorCode(err) != hive.ErrNodeNotFound {
+		t.Errorf("Home.SetHolidayMode() error = %v, want %v", err, hive.ErrNodeNotFound)
+	}
+
+	if err := home.CancelHolidayMode(); hive.ErrorCode(err) != hive.ErrNodeNotFound {
+		t.Errorf("Home.CancelHolidayMode() error = %v, want %v", err, hive.ErrNodeNotFound)
+	}
+}
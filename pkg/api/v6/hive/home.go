@@ -1,11 +1,18 @@
 package hive
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -13,17 +20,42 @@ const (
 	DefaultURL = "https://api-prod.bgchprod.info"
 )
 
-// Home communicates with the Hive API
+// Home communicates with the Hive API, it is safe for concurrent use by
+// multiple goroutines along with the devices it returns.
 type Home struct {
 	baseURL    *url.URL
 	username   string
 	password   string
 	httpClient *http.Client
-	sessionID  string
+	store      SessionStore
+
+	retryPolicy RetryPolicy
+
+	sessionMu sync.RWMutex
+	session   Session
+	closed    bool
+	loginMu   sync.Mutex
+
+	nodeMu    sync.RWMutex
+	devicesMu sync.Mutex
+	devices   map[deviceKey]device
+	missing   map[deviceKey]bool
+
+	confirmationInterval time.Duration
+	pendingMu            sync.Mutex
+	requestedAt          map[pendingKey]time.Time
+
+	clk clock
 }
 
 // Connect establishes a new connection to the Hive API
 func Connect(options ...Option) (*Home, error) {
+	return ConnectContext(context.Background(), options...)
+}
+
+// ConnectContext establishes a new connection to the Hive API using
+// the provided context for the login request.
+func ConnectContext(ctx context.Context, options ...Option) (*Home, error) {
 	opts := defaultOptions
 	for _, opt := range options {
 		opt(&opts)
@@ -35,10 +67,15 @@ func Connect(options ...Option) (*Home, error) {
 	}
 
 	home := &Home{
-		baseURL:    base,
-		username:   opts.username,
-		password:   opts.password,
-		httpClient: opts.httpClient,
+		baseURL:     base,
+		username:    opts.username,
+		password:    opts.password,
+		httpClient:  opts.httpClient,
+		retryPolicy: opts.retryPolicy,
+		session:     opts.session,
+		store:       opts.sessionStore,
+
+		confirmationInterval: opts.confirmationInterval,
 	}
 
 	if opts.tlsConfig != nil {
@@ -47,14 +84,30 @@ func Connect(options ...Option) (*Home, error) {
 		}
 	}
 
-	if err := home.login(); err != nil {
+	if home.session.ID == "" && home.store != nil {
+		// A store which cannot be loaded, such as a corrupt session
+		// file, is treated as having no session so the login below
+		// replaces it.
+		s, _ := home.store.Load()
+
+		// Only reuse a stored session belonging to the configured user
+		if s.Username == home.username {
+			home.session = s
+		}
+	}
+
+	if home.session.ID != "" {
+		return home, nil
+	}
+
+	if err := home.login(ctx); err != nil {
 		return nil, err
 	}
 
 	return home, nil
 }
 
-func (home *Home) newRequest(method, path string, body io.Reader) (*http.Request, error) {
+func (home *Home) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
 	const mimeType = "application/vnd.alertme.zoo-6.1+json"
 
 	uri, err := home.baseURL.Parse(path)
@@ -62,7 +115,7 @@ func (home *Home) newRequest(method, path string, body io.Reader) (*http.Request
 		return nil, err
 	}
 
-	req, err := http.NewRequest(method, uri.String(), body)
+	req, err := http.NewRequestWithContext(ctx, method, uri.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -75,65 +128,140 @@ func (home *Home) newRequest(method, path string, body io.Reader) (*http.Request
 	req.Header.Set("Accept", mimeType)
 	req.Header.Set("X-Omnia-Client", "Hive Web Dashboard")
 
-	if home.sessionID != "" {
-		req.Header.Set("X-Omnia-Access-Token", home.sessionID)
+	if id := home.Session().ID; id != "" {
+		req.Header.Set("X-Omnia-Access-Token", id)
 	}
 
 	return req, nil
 }
 
+// maxErrorBodySize limits how much of an error response body is read
+const maxErrorBodySize = 64 << 10
+
 type errorResponse struct {
-	Errors []struct {
-		Code  string `json:"code"`
-		Title string `json:"title"`
-		// Links []unknown
-	} `json:"errors"`
+	Errors []APIError `json:"errors"`
 }
 
-func (home *Home) checkResponse(resp *http.Response) error {
-	switch resp.StatusCode {
-	case http.StatusOK:
+// checkResponse returns an Error describing resp unless it was
+// successful, path is the path of the request resp answers.
+func (home *Home) checkResponse(resp *http.Response, path string) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		return nil
 	}
 
-	var body errorResponse
-	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
-		return &Error{Err: err, Code: ErrInvalidJSON}
+	status := resp.StatusCode
+
+	body := bufio.NewReader(io.LimitReader(resp.Body, maxErrorBodySize))
+	if !isJSONResponse(resp.Header.Get("Content-Type"), body) {
+		return &Error{
+			Code:       ErrUnexpectedResponse,
+			Message:    fmt.Sprintf("unexpected %v response", resp.Status),
+			StatusCode: status,
+			Path:       path,
+		}
+	}
+
+	var er errorResponse
+	if err := json.NewDecoder(body).Decode(&er); err != nil {
+		return &Error{Err: err, Code: ErrInvalidJSON, StatusCode: status, Path: path}
+	}
+
+	if len(er.Errors) == 0 {
+		return &Error{Message: "unknown error response", StatusCode: status, Path: path}
 	}
 
-	if len(body.Errors) == 0 {
-		return &Error{Message: "unknown error response"}
+	titles := make([]string, len(er.Errors))
+	for i, e := range er.Errors {
+		titles[i] = e.Title
 	}
 
-	e := body.Errors[0]
-	return &Error{Code: e.Code, Message: e.Title}
+	return &Error{
+		Code:       er.Errors[0].Code,
+		Message:    strings.Join(titles, "; "),
+		Errors:     er.Errors,
+		StatusCode: status,
+		Path:       path,
+	}
 }
 
-func (home *Home) httpRequest(method, path string, body io.Reader) (*http.Response, error) {
-	req, err := home.newRequest(method, path, body)
-	if err != nil {
-		return nil, &Error{Op: "home: request", Err: err}
+// isJSONResponse reports whether an error body should be decoded as JSON.
+// Bodies labelled as plain text, or not labelled at all, are decoded when
+// they start like a JSON object. Anything else, such as a HTML error page
+// from a proxy, is not.
+func isJSONResponse(contentType string, body *bufio.Reader) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return true
+	case mediaType != "" && mediaType != "text/plain":
+		return false
 	}
 
-	resp, err := home.httpClient.Do(req)
-	if err != nil {
-		return nil, &Error{Op: "home: response", Err: err}
+	for {
+		c, err := body.ReadByte()
+		if err != nil {
+			return false
+		}
+
+		if c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			body.UnreadByte()
+			return c == '{'
+		}
 	}
+}
 
-	if err := home.checkResponse(resp); err != nil {
-		io.Copy(ioutil.Discard, resp.Body)
-		resp.Body.Close()
-		return nil, err
+func (home *Home) httpRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	if home.isClosed() {
+		return nil, &Error{Op: "home: request", Code: ErrClosed, Message: "home has been closed"}
 	}
 
-	return resp, nil
+	for attempt := 1; ; attempt++ {
+		req, err := home.newRequest(ctx, method, path, body)
+		if err != nil {
+			return nil, &Error{Op: "home: request", Err: err}
+		}
+
+		resp, err := home.httpClient.Do(req)
+		if ctx.Err() == nil {
+			if d, ok := home.retryPolicy.delay(attempt, method, resp, err); ok && rewind(body) {
+				if resp != nil {
+					io.Copy(ioutil.Discard, resp.Body)
+					resp.Body.Close()
+				}
+
+				if err := sleep(ctx, d); err != nil {
+					return nil, &Error{Op: "home: request retry", Err: err}
+				}
+
+				continue
+			}
+		}
+
+		if err != nil {
+			return nil, &Error{Op: "home: response", Err: err}
+		}
+
+		if err := home.checkResponse(resp, req.URL.Path); err != nil {
+			io.CopyN(ioutil.Discard, resp.Body, maxErrorBodySize)
+			resp.Body.Close()
+			return nil, err
+		}
+
+		return resp, nil
+	}
 }
 
-func (home *Home) httpRequestWithSession(method, path string, body io.ReadSeeker) (*http.Response, error) {
-	resp, err := home.httpRequest(method, path, body)
+func (home *Home) httpRequestWithSession(ctx context.Context, method, path string, body io.ReadSeeker) (*http.Response, error) {
+	stale := home.Session().ID
+	resp, err := home.httpRequest(ctx, method, path, body)
 
 	if ErrorCode(err) == ErrNotAuthorized {
-		if err := home.login(); err != nil {
+		if err := ctx.Err(); err != nil {
+			return nil, &Error{Op: "home: request retry", Err: err}
+		}
+
+		if err := home.relogin(ctx, stale); err != nil {
 			return nil, err
 		}
 
@@ -146,7 +274,7 @@ func (home *Home) httpRequestWithSession(method, path string, body io.ReadSeeker
 			}
 		}
 
-		return home.httpRequest(method, path, body)
+		return home.httpRequest(ctx, method, path, body)
 	}
 
 	return resp, err
@@ -0,0 +1,150 @@
+package hive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// countingReader counts the bytes read from it
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestHome_checkResponse(t *testing.T) {
+	const contentType = "application/vnd.alertme.zoo-6.1+json;charset=UTF-8"
+
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		wantErr     bool
+		code        string
+		message     string
+	}{
+		{"OK", http.StatusOK, contentType, `{"nodes":[]}`, false, "", ""},
+		{"Created", http.StatusCreated, contentType, `{}`, false, "", ""},
+		{"No Content", http.StatusNoContent, "", "", false, "", ""},
+		{"API Error", http.StatusUnauthorized, contentType,
+			`{"errors":[{"code":"NOT_AUTHORIZED","title":"Not authorized","links":[]}]}`,
+			true, ErrNotAuthorized, "Not authorized"},
+		{"Plain Text JSON", http.StatusBadRequest, "text/plain; charset=utf-8",
+			`{"errors":[{"code":"MISSING_PARAMETER","title":"Missing","links":[]}]}`,
+			true, ErrMissingParameter, "Missing"},
+		{"Multiple Errors", http.StatusBadRequest, contentType,
+			`{"errors":[{"code":"MISSING_PARAMETER","title":"Missing"},{"code":"USERNAME_PASSWORD_ERROR","title":"Invalid"}]}`,
+			true, ErrMissingParameter, "Missing; Invalid"},
+		{"HTML Error Page", http.StatusBadGateway, "text/html",
+			`<html><body>secret upstream details</body></html>`,
+			true, ErrUnexpectedResponse, "unexpected 502 Bad Gateway response"},
+		{"Plain Text Error", http.StatusServiceUnavailable, "text/plain",
+			`upstream connect error`,
+			true, ErrUnexpectedResponse, "unexpected 503 Service Unavailable response"},
+		{"Invalid JSON", http.StatusInternalServerError, contentType, `{"errors":`,
+			true, ErrInvalidJSON, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := &Home{}
+			resp := &http.Response{
+				Status:     fmt.Sprintf("%d %s", tt.status, http.StatusText(tt.status)),
+				StatusCode: tt.status,
+				Header:     http.Header{"Content-Type": {tt.contentType}},
+				Body:       ioutil.NopCloser(strings.NewReader(tt.body)),
+			}
+
+			err := home.checkResponse(resp, "/omnia/nodes")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Home.checkResponse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil {
+				return
+			}
+
+			if !HasCode(err, tt.code) {
+				t.Errorf("Home.checkResponse() error = %v, want code %v", err, tt.code)
+			}
+
+			if tt.message != "" && ErrorMessage(err) != tt.message {
+				t.Errorf("Home.checkResponse() message = %q, want %q", ErrorMessage(err), tt.message)
+			}
+
+			if StatusCode(err) != tt.status {
+				t.Errorf("Home.checkResponse() status = %v, want %v", StatusCode(err), tt.status)
+			}
+
+			if e := err.(*Error); e.Path != "/omnia/nodes" {
+				t.Errorf("Home.checkResponse() path = %v, want /omnia/nodes", e.Path)
+			}
+		})
+	}
+
+	t.Run("Multiple Errors Is", func(t *testing.T) {
+		err := (&Home{}).checkResponse(&http.Response{
+			StatusCode: http.StatusBadRequest,
+			Header:     http.Header{"Content-Type": {contentType}},
+			Body: ioutil.NopCloser(strings.NewReader(
+				`{"errors":[{"code":"MISSING_PARAMETER","title":"Missing"},{"code":"USERNAME_PASSWORD_ERROR","title":"Invalid"}]}`)),
+		}, "/omnia/auth/sessions")
+
+		if !HasCode(err, ErrInvalidCredentials) {
+			t.Errorf("Home.checkResponse() error = %v, want %v", err, ErrInvalidCredentials)
+		}
+	})
+
+	t.Run("Response Without Request", func(t *testing.T) {
+		baseURL, _ := url.Parse("https://hive.invalid")
+		home := &Home{
+			baseURL: baseURL,
+			httpClient: &http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+				return &http.Response{
+					Status:     "404 Not Found",
+					StatusCode: http.StatusNotFound,
+					Header:     http.Header{"Content-Type": {contentType}},
+					Body:       ioutil.NopCloser(strings.NewReader(`{"errors":[{"code":"NOT_FOUND","title":"Not found"}]}`)),
+				}, nil
+			})},
+		}
+
+		_, err := home.httpRequest(context.Background(), http.MethodGet, "/omnia/nodes/missing", nil)
+		if e, ok := err.(*Error); !ok || e.Path != "/omnia/nodes/missing" || e.StatusCode != http.StatusNotFound {
+			t.Errorf("Home.httpRequest() error = %#v, want 404 for /omnia/nodes/missing", err)
+		}
+	})
+
+	t.Run("Large Body", func(t *testing.T) {
+		body := &countingReader{r: strings.NewReader("{" + strings.Repeat(" ", 4*maxErrorBodySize))}
+
+		err := (&Home{}).checkResponse(&http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Header:     http.Header{"Content-Type": {contentType}},
+			Body:       ioutil.NopCloser(body),
+		}, "/omnia/nodes")
+
+		if ErrorCode(err) != ErrInvalidJSON {
+			t.Errorf("Home.checkResponse() error = %v, want %v", err, ErrInvalidJSON)
+		}
+
+		if body.n > maxErrorBodySize {
+			t.Errorf("Home.checkResponse() read %v bytes, want <= %v", body.n, maxErrorBodySize)
+		}
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
@@ -1,6 +1,7 @@
 package hive_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -131,3 +132,95 @@ func TestHomeConnect(t *testing.T) {
 		})
 	}
 }
+
+func TestHomeConnectContext(t *testing.T) {
+	var logins int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logins++
+		http.Error(w, "unexpected request", http.StatusInternalServerError)
+	}))
+
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	home, err := hive.ConnectContext(ctx,
+		hive.WithCredentials("username", "password"),
+		hive.WithHTTPClient(srv.Client()),
+		hive.WithURL(srv.URL),
+	)
+
+	if err == nil {
+		t.Errorf("hive.ConnectContext() error = %v, want != nil", err)
+	}
+
+	if home != nil {
+		t.Errorf("hive.ConnectContext() home = %v, want nil", home)
+	}
+
+	if logins != 0 {
+		t.Errorf("hive.ConnectContext() logins = %v, want 0", logins)
+	}
+}
+
+func TestHomeContextCancelledLoginRetry(t *testing.T) {
+	var (
+		logins int
+		cancel context.CancelFunc
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.alertme.zoo-6.1+json;charset=UTF-8")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/omnia/auth/sessions":
+			logins++
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{
+				"sessions":[{
+					"id":"4wdz82NrUmdYCuuNz3wzofWGymjRWigL",
+					"username":"username",
+					"userId":"b3a1835b-d27a-4ce9-b095-830fe9f0e398",
+					"extCustomerLevel":1,
+					"latestSupportedApiVersion":"6",
+					"sessionId":"4wdz82NrUmdYCuuNz3wzofWGymjRWigL"
+				}]
+			}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/omnia/nodes":
+			// Cancel the callers context before reporting the expired
+			// session, the client must not attempt to login again.
+			cancel()
+
+			http.Error(w,
+				`{"errors":[{"code":"NOT_AUTHORIZED","title":"Not authorized","links":[]}]}`,
+				http.StatusUnauthorized)
+		default:
+			http.Error(w, "unknown path", http.StatusNotFound)
+		}
+	}))
+
+	defer srv.Close()
+
+	home, err := hive.Connect(
+		hive.WithCredentials("username", "password"),
+		hive.WithHTTPClient(srv.Client()),
+		hive.WithURL(srv.URL),
+	)
+	if err != nil {
+		t.Fatalf("hive.Connect() error = %v, want nil", err)
+	}
+
+	var ctx context.Context
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := home.ThermostatsContext(ctx); err == nil {
+		t.Errorf("Home.ThermostatsContext() error = %v, want != nil", err)
+	}
+
+	if logins != 1 {
+		t.Errorf("Home.ThermostatsContext() logins = %v, want 1", logins)
+	}
+}
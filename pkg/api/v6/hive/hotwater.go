@@ -0,0 +1,194 @@
+package hive
+
+import "context"
+
+// HotWaterMode defines the operating mode of the hot water
+type HotWaterMode int
+
+// HotWaterMode values
+const (
+	HotWaterModeOff HotWaterMode = iota
+	HotWaterModeOn
+	HotWaterModeSchedule
+)
+
+// HotWater is a Hive managed hot water channel
+type HotWater struct {
+	home *Home
+	node *node
+
+	ID   string
+	Name string
+	Href string
+}
+
+// Mode returns the current operating mode of the hot water. While
+// boosting the hot water reports HotWaterModeOn.
+func (hw *HotWater) Mode() (HotWaterMode, error) {
+	mode, ok := hw.current().attr("activeHeatCoolMode").ReportedValueString()
+	if !ok {
+		return HotWaterModeOff, &Error{
+			Op:      "hot water: mode",
+			Code:    ErrInvalidDataType,
+			Message: "invalid data type",
+		}
+	}
+
+	switch mode {
+	case "HEAT":
+		break
+	case "BOOST":
+		return HotWaterModeOn, nil
+	default:
+		return HotWaterModeOff, nil
+	}
+
+	lock, ok := hw.current().attr("activeScheduleLock").ReportedValueBool()
+	if !ok {
+		return HotWaterModeOff, &Error{
+			Op:      "hot water: mode",
+			Code:    ErrInvalidDataType,
+			Message: "invalid data type",
+		}
+	}
+
+	if lock {
+		return HotWaterModeOn, nil
+	}
+
+	return HotWaterModeSchedule, nil
+}
+
+// Relay returns true if the hot water relay is currently on
+func (hw *HotWater) Relay() (bool, error) {
+	v, ok := hw.current().attr("stateHotWaterRelay").ReportedValueString()
+	if !ok {
+		return false, &Error{
+			Op:      "hot water: relay",
+			Code:    ErrInvalidDataType,
+			Message: "invalid data type",
+		}
+	}
+
+	return v == "ON", nil
+}
+
+// Boosting returns true if the hot water is currently being boosted
+func (hw *HotWater) Boosting() (bool, error) {
+	v, ok := hw.current().attr("activeHeatCoolMode").ReportedValueString()
+	if !ok {
+		return false, &Error{
+			Op:      "hot water: boosting",
+			Code:    ErrInvalidDataType,
+			Message: "invalid data type",
+		}
+	}
+
+	return v == "BOOST", nil
+}
+
+// Update fetches the latest information about the HotWater from the API
+func (hw *HotWater) Update() error {
+	return hw.UpdateContext(context.Background())
+}
+
+// UpdateContext fetches the latest information about the HotWater from
+// the API using the provided context.
+func (hw *HotWater) UpdateContext(ctx context.Context) error {
+	n, err := hw.home.node(ctx, hw.Href)
+	if err != nil {
+		return &Error{Op: "hot water: update", Err: err}
+	}
+
+	if n.ID != hw.ID {
+		return &Error{Op: "hot water: update", Code: ErrInvalidUpdate, Message: "update failed, ID mismatch"}
+	}
+
+	hw.refresh(n)
+	return nil
+}
+
+// HotWater returns the list of hot water channels in the Home
+func (home *Home) HotWater() ([]*HotWater, error) {
+	return home.HotWaterContext(context.Background())
+}
+
+// HotWaterContext returns the list of hot water channels in the Home
+// using the provided context.
+func (home *Home) HotWaterContext(ctx context.Context) ([]*HotWater, error) {
+	nodes, err := home.nodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return home.newHotWater(nodes), nil
+}
+
+// newHotWater returns a HotWater for each hot water channel in nodes
+func (home *Home) newHotWater(nodes []*node) []*HotWater {
+	var hotWater []*HotWater
+
+	for _, n := range nodes {
+		nt, err := n.NodeType()
+		if err != nil || nt != nodeTypeThermostat {
+			continue
+		}
+
+		if _, ok := n.Attributes["stateHotWaterRelay"]; !ok {
+			continue
+		}
+
+		n := n
+		hotWater = append(hotWater, home.track(&HotWater{
+			ID:   n.ID,
+			Name: n.Name,
+			Href: n.Href,
+			home: home,
+			node: n,
+		}).(*HotWater))
+	}
+
+	return hotWater
+}
+
+// SetMode sets the operating mode of the HotWater
+func (hw *HotWater) SetMode(mode HotWaterMode) error {
+	return hw.SetModeContext(context.Background(), mode)
+}
+
+// SetModeContext sets the operating mode of the HotWater using the
+// provided context.
+func (hw *HotWater) SetModeContext(ctx context.Context, mode HotWaterMode) error {
+	attrs, err := hotWaterModeAttributes(mode)
+	if err != nil {
+		return err
+	}
+
+	return hw.home.setAttributes(ctx, "hot water: set mode", hw, attrs)
+}
+
+// hotWaterModeAttributes returns the node attributes which select the mode
+func hotWaterModeAttributes(mode HotWaterMode) (nodeAttributes, error) {
+	switch mode {
+	case HotWaterModeOff:
+		return nodeAttributes{
+			"activeHeatCoolMode": {TargetValue: "OFF"},
+		}, nil
+	case HotWaterModeOn:
+		return nodeAttributes{
+			"activeHeatCoolMode": {TargetValue: "HEAT"},
+			"activeScheduleLock": {TargetValue: true},
+		}, nil
+	case HotWaterModeSchedule:
+		return nodeAttributes{
+			"activeHeatCoolMode": {TargetValue: "HEAT"},
+			"activeScheduleLock": {TargetValue: false},
+		}, nil
+	default:
+		return nil, &Error{
+			Op:      "hot water: set mode",
+			Code:    ErrInvalidDataType,
+			Message: "unknown hot water mode",
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package hive
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+const hotWaterNodeJSON = `{
+	"id": "8a2b9a9c-7c3d-4b8e-bd1a-6e0a5d5b0c11",
+	"href": "https://api-prod.bgchprod.info/omnia/nodes/8a2b9a9c-7c3d-4b8e-bd1a-6e0a5d5b0c11",
+	"name": "Receiver 1",
+	"parentNodeId": "1e32b7bd-64c1-46d8-812c-d4b339e8ac75",
+	"lastSeen": 1530553614549,
+	"createdOn": 1503399128592,
+	"userId": "e50c9b24-b45c-4cc6-b209-a32fb267ef9f",
+	"ownerId": "e50c9b24-b45c-4cc6-b209-a32fb267ef9f",
+	"homeId": "2f259ff3-108e-4bb8-b52b-d31c5a302d01",
+	"attributes": {
+		"activeHeatCoolMode": {
+			"reportedValue": %q,
+			"displayValue": %q,
+			"reportReceivedTime": 1541629836583,
+			"reportChangedTime": 1528575087449
+		},
+		"activeScheduleLock": {
+			"reportedValue": %v,
+			"displayValue": %v,
+			"reportReceivedTime": 1541629836583,
+			"reportChangedTime": 1528575087449
+		},
+		"stateHotWaterRelay": {
+			"reportedValue": "ON",
+			"displayValue": "ON",
+			"reportReceivedTime": 1541629836583,
+			"reportChangedTime": 1541624410903
+		},
+		"nodeType": {
+			"reportedValue": "http://alertme.com/schema/json/node.class.thermostat.json#",
+			"displayValue": "http://alertme.com/schema/json/node.class.thermostat.json#",
+			"reportReceivedTime": 1541630239844,
+			"reportChangedTime": 1528575087449
+		}
+	}
+}`
+
+func hotWaterNode(mode string, lock bool) string {
+	return fmt.Sprintf(hotWaterNodeJSON, mode, mode, lock, lock)
+}
+
+func TestHotWater_Mode(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    interface{}
+		lock    interface{}
+		want    HotWaterMode
+		wantErr bool
+	}{
+		{"Off", "OFF", false, HotWaterModeOff, false},
+		{"On", "HEAT", true, HotWaterModeOn, false},
+		{"Schedule", "HEAT", false, HotWaterModeSchedule, false},
+		{"Boost", "BOOST", false, HotWaterModeOn, false},
+		{"InvalidMode", 100, false, HotWaterModeOff, true},
+		{"InvalidLock", "HEAT", "yes", HotWaterModeOff, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hw := &HotWater{
+				node: &node{
+					Attributes: nodeAttributes{
+						"activeHeatCoolMode": &nodeAttribute{
+							ReportedValue: tt.mode,
+						},
+						"activeScheduleLock": &nodeAttribute{
+							ReportedValue: tt.lock,
+						},
+					},
+				},
+			}
+			got, err := hw.Mode()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HotWater.Mode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("HotWater.Mode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHotWater_Relay(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    bool
+		wantErr bool
+	}{
+		{"On", "ON", true, false},
+		{"Off", "OFF", false, false},
+		{"Invalid", 100, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hw := &HotWater{
+				node: &node{
+					Attributes: nodeAttributes{
+						"stateHotWaterRelay": &nodeAttribute{
+							ReportedValue: tt.value,
+						},
+					},
+				},
+			}
+			got, err := hw.Relay()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HotWater.Relay() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("HotWater.Relay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHotWater_Boosting(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    bool
+		wantErr bool
+	}{
+		{"Boost", "BOOST", true, false},
+		{"Heat", "HEAT", false, false},
+		{"Invalid", 100, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hw := &HotWater{
+				node: &node{
+					Attributes: nodeAttributes{
+						"activeHeatCoolMode": &nodeAttribute{
+							ReportedValue: tt.value,
+						},
+					},
+				},
+			}
+			got, err := hw.Boosting()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HotWater.Boosting() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("HotWater.Boosting() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHome_HotWater(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "must be get", http.StatusBadRequest)
+			return
+		}
+
+		if r.URL.Path != "/omnia/nodes" {
+			http.Error(w, "unknown path", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.alertme.zoo-6.1+json;charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{
+			"meta": {},
+			"links": {},
+			"linked": {},
+			"nodes": [%s, {
+				"id": "fe49e95e-c8cc-47cc-b38f-ec0c06361e13",
+				"href": "https://api-prod.bgchprod.info/omnia/nodes/fe49e95e-c8cc-47cc-b38f-ec0c06361e13",
+				"name": "Receiver 1",
+				"attributes": {
+					"temperature": {
+						"reportedValue": 17.67,
+						"displayValue": 17.67
+					},
+					"nodeType": {
+						"reportedValue": "http://alertme.com/schema/json/node.class.thermostat.json#",
+						"displayValue": "http://alertme.com/schema/json/node.class.thermostat.json#"
+					}
+				}
+			}]
+		}`, hotWaterNode("HEAT", false))
+	}))
+
+	defer srv.Close()
+
+	baseURL, _ := url.Parse(srv.URL)
+	home := &Home{
+		baseURL:    baseURL,
+		httpClient: srv.Client(),
+	}
+
+	got, err := home.HotWater()
+	if err != nil {
+		t.Fatalf("Home.HotWater() error = %v, want nil", err)
+	}
+
+	want := []*HotWater{{
+		ID:   "8a2b9a9c-7c3d-4b8e-bd1a-6e0a5d5b0c11",
+		Name: "Receiver 1",
+		Href: "https://api-prod.bgchprod.info/omnia/nodes/8a2b9a9c-7c3d-4b8e-bd1a-6e0a5d5b0c11",
+		home: home,
+	}}
+
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Home.HotWater() = %v, want %v, diff = %v", got, want, diff)
+	}
+}
+
+func TestHotWater_SetMode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			http.Error(w, "must be put", http.StatusBadRequest)
+			return
+		}
+
+		if r.URL.Path != "/omnia/nodes/8a2b9a9c-7c3d-4b8e-bd1a-6e0a5d5b0c11" {
+			http.Error(w, "unknown path", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.alertme.zoo-6.1+json;charset=UTF-8")
+
+		var req nodesResponse
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Nodes) != 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors": [{"code": "INVALID_PARAMETER","title": "Node configuration error", "links": []}]}`))
+			return
+		}
+
+		mode, _ := req.Nodes[0].attr("activeHeatCoolMode").TargetValueString()
+		lock, _ := req.Nodes[0].attr("activeScheduleLock").TargetValueBool()
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"nodes": [%s]}`, hotWaterNode(mode, lock))
+	}))
+
+	defer srv.Close()
+
+	baseURL, _ := url.Parse(srv.URL)
+	home := &Home{
+		baseURL:    baseURL,
+		httpClient: srv.Client(),
+	}
+
+	tests := []struct {
+		name    string
+		mode    HotWaterMode
+		wantErr bool
+	}{
+		{"Off", HotWaterModeOff, false},
+		{"On", HotWaterModeOn, false},
+		{"Schedule", HotWaterModeSchedule, false},
+		{"Invalid", HotWaterMode(100), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hw := &HotWater{
+				ID:   "8a2b9a9c-7c3d-4b8e-bd1a-6e0a5d5b0c11",
+				Name: "Receiver 1",
+				Href: "https://api-prod.bgchprod.info/omnia/nodes/8a2b9a9c-7c3d-4b8e-bd1a-6e0a5d5b0c11",
+				home: home,
+			}
+
+			err := hw.SetMode(tt.mode)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HotWater.SetMode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got, _ := hw.Mode(); got != tt.mode {
+				t.Errorf("HotWater.Mode() = %v, want %v", got, tt.mode)
+			}
+		})
+	}
+}
@@ -2,9 +2,11 @@ package hive
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 type loginResponse struct {
@@ -20,12 +22,13 @@ type session struct {
 	SessionID                 string `json:"sessionId,omitempty"`
 }
 
-func (home *Home) login() error {
-	body := &bytes.Buffer{}
-	fmt.Fprintf(body, `{"sessions":[{"username":%q,"password":%q,"caller":"WEB"}]}`,
+func (home *Home) login(ctx context.Context) error {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, `{"sessions":[{"username":%q,"password":%q,"caller":"WEB"}]}`,
 		home.username, home.password)
 
-	resp, err := home.httpRequest(http.MethodPost, "/omnia/auth/sessions", body)
+	body := bytes.NewReader(buf.Bytes()) // support rewinding the body for retries
+	resp, err := home.httpRequest(ctx, http.MethodPost, "/omnia/auth/sessions", body)
 	if err != nil {
 		return &Error{Op: "login: request", Err: err}
 	}
@@ -42,7 +45,36 @@ func (home *Home) login() error {
 	}
 
 	session := response.Sessions[0]
-	home.sessionID = session.SessionID
+	s := Session{
+		ID:         session.SessionID,
+		Username:   home.username,
+		UserID:     session.UserID,
+		APIVersion: session.LatestSupportedAPIVersion,
+		IssuedAt:   time.Now(),
+	}
+
+	home.setSession(s)
+
+	// Persisting the session is best-effort, the session is usable even
+	// if it cannot be reused by the next connection.
+	if home.store != nil {
+		_ = home.store.Save(s)
+	}
 
 	return nil
 }
+
+// relogin replaces the session which was rejected as stale. Concurrent
+// callers rejected with the same session wait for a single login rather
+// than each logging in.
+func (home *Home) relogin(ctx context.Context, stale string) error {
+	home.loginMu.Lock()
+	defer home.loginMu.Unlock()
+
+	if home.Session().ID != stale {
+		// Another caller has already logged in again
+		return nil
+	}
+
+	return home.login(ctx)
+}
@@ -0,0 +1,91 @@
+package hive_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/geoffgarside/homekit-hive/pkg/api/v6/hive"
+)
+
+func TestHomeLogout(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{"Deleted", http.StatusOK, false},
+		{"Expired", http.StatusUnauthorized, false},
+		{"Failed", http.StatusBadRequest, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deletes, requests int
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.Header().Set("Content-Type", "application/vnd.alertme.zoo-6.1+json;charset=UTF-8")
+
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/omnia/auth/sessions":
+					fmt.Fprint(w, `{"sessions":[{"userId":"b3a1835b","latestSupportedApiVersion":"6","sessionId":"4wdz82NrUmdYCuuNz3wzofWGymjRWigL"}]}`)
+				case r.Method == http.MethodDelete && r.URL.Path == "/omnia/auth/sessions/4wdz82NrUmdYCuuNz3wzofWGymjRWigL":
+					deletes++
+
+					if r.Header.Get("X-Omnia-Access-Token") != "4wdz82NrUmdYCuuNz3wzofWGymjRWigL" {
+						t.Errorf("Home.Logout() token = %q", r.Header.Get("X-Omnia-Access-Token"))
+					}
+
+					switch tt.status {
+					case http.StatusOK:
+						fmt.Fprint(w, `{}`)
+					case http.StatusUnauthorized:
+						http.Error(w, `{"errors":[{"code":"NOT_AUTHORIZED","title":"Not authorized","links":[]}]}`, tt.status)
+					default:
+						http.Error(w, `{"errors":[{"code":"INVALID_PARAMETER","title":"bad request","links":[]}]}`, tt.status)
+					}
+				default:
+					http.Error(w, "unknown path", http.StatusNotFound)
+				}
+			}))
+
+			defer srv.Close()
+
+			home, err := hive.Connect(
+				hive.WithCredentials("username", "password"),
+				hive.WithHTTPClient(srv.Client()),
+				hive.WithURL(srv.URL),
+			)
+			if err != nil {
+				t.Fatalf("hive.Connect() error = %v, want nil", err)
+			}
+
+			if err := home.Logout(); (err != nil) != tt.wantErr {
+				t.Errorf("Home.Logout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if deletes != 1 {
+				t.Errorf("Home.Logout() deletes = %v, want 1", deletes)
+			}
+
+			if s := home.Session(); s.ID != "" {
+				t.Errorf("Home.Session() = %+v, want zero Session", s)
+			}
+
+			requests = 0
+
+			if _, err := home.Thermostats(); hive.ErrorCode(err) != hive.ErrClosed {
+				t.Errorf("Home.Thermostats() error = %v, want %v", err, hive.ErrClosed)
+			}
+
+			if err := home.Close(); err != nil {
+				t.Errorf("Home.Close() error = %v, want nil", err)
+			}
+
+			if requests != 0 {
+				t.Errorf("closed Home requests = %v, want 0", requests)
+			}
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package hive
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 )
 
 type node struct {
@@ -50,6 +53,16 @@ func (n *node) attr(key string) *nodeAttribute {
 	return a
 }
 
+// millisTime converts a millisecond timestamp from the API into a
+// time.Time, returning the zero time when ms is zero.
+func millisTime(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
+
 type nodeAttribute struct {
 	ReportedValue      interface{} `json:"reportedValue,omitempty"`
 	DisplayValue       interface{} `json:"displayValue,omitempty"`
@@ -58,6 +71,36 @@ type nodeAttribute struct {
 	ReportChangedTime  int64       `json:"reportChangedTime,omitempty"`  // 1528575087449
 }
 
+// UnmarshalJSON decodes the attribute keeping numeric values as
+// json.Number, so integers are not rounded through float64 and the
+// integer accessors can be used on values from the API.
+func (na *nodeAttribute) UnmarshalJSON(b []byte) error {
+	type plain nodeAttribute
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	return dec.Decode((*plain)(na))
+}
+
+func (na *nodeAttribute) float64(v interface{}) (f float64, ok bool) {
+	switch ff := v.(type) {
+	case float64:
+		return ff, true
+	case float32:
+		return float64(ff), true
+	case json.Number:
+		f, err := ff.Float64()
+		return f, err == nil
+	}
+
+	if i, ok := na.int64(v); ok {
+		return float64(i), true
+	}
+
+	return 0, false
+}
+
 func (na *nodeAttribute) int64(v interface{}) (i int64, ok bool) {
 	ok = true
 
@@ -72,6 +115,10 @@ func (na *nodeAttribute) int64(v interface{}) (i int64, ok bool) {
 		i = int64(ii)
 	case int64:
 		i = int64(ii)
+	case json.Number:
+		var err error
+		i, err = ii.Int64()
+		ok = err == nil
 	default:
 		ok = false
 	}
@@ -103,6 +150,10 @@ func (na *nodeAttribute) uint64(v interface{}) (i uint64, ok bool) {
 		i = uint64(ii)
 	case uint64:
 		i = uint64(ii)
+	case json.Number:
+		var err error
+		i, err = strconv.ParseUint(string(ii), 10, 64)
+		ok = err == nil
 	default:
 		ok = false
 	}
@@ -110,6 +161,18 @@ func (na *nodeAttribute) uint64(v interface{}) (i uint64, ok bool) {
 	return
 }
 
+// decodeReportedValue decodes a structured reported value into v. The
+// value has already been decoded into generic maps, so it is round
+// tripped through JSON.
+func (na *nodeAttribute) decodeReportedValue(v interface{}) error {
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(na.ReportedValue); err != nil {
+		return err
+	}
+
+	return json.NewDecoder(buf).Decode(v)
+}
+
 func (na *nodeAttribute) ReportedValueString() (s string, ok bool) {
 	s, ok = na.ReportedValue.(string)
 	return
@@ -121,8 +184,7 @@ func (na *nodeAttribute) ReportedValueBool() (b bool, ok bool) {
 }
 
 func (na *nodeAttribute) ReportedValueFloat() (f float64, ok bool) {
-	f, ok = na.ReportedValue.(float64)
-	return
+	return na.float64(na.ReportedValue)
 }
 
 func (na *nodeAttribute) ReportedValueInt() (i int64, ok bool) {
@@ -144,8 +206,7 @@ func (na *nodeAttribute) DisplayValueBool() (b bool, ok bool) {
 }
 
 func (na *nodeAttribute) DisplayValueFloat() (f float64, ok bool) {
-	f, ok = na.DisplayValue.(float64)
-	return
+	return na.float64(na.DisplayValue)
 }
 
 func (na *nodeAttribute) DisplayValueInt() (i int64, ok bool) {
@@ -167,8 +228,7 @@ func (na *nodeAttribute) TargetValueBool() (b bool, ok bool) {
 }
 
 func (na *nodeAttribute) TargetValueFloat() (f float64, ok bool) {
-	f, ok = na.TargetValue.(float64)
-	return
+	return na.float64(na.TargetValue)
 }
 
 func (na *nodeAttribute) TargetValueInt() (i int64, ok bool) {
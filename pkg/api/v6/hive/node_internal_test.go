@@ -1,6 +1,7 @@
 package hive
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 )
@@ -201,3 +202,54 @@ func Test_nodeAttribute_uint64(t *testing.T) {
 		})
 	}
 }
+
+func Test_nodeAttribute_decoded(t *testing.T) {
+	tests := []struct {
+		name   string
+		json   string
+		method string
+		want   interface{}
+		wantOk bool
+	}{
+		{"Float", `{"reportedValue": 19.5}`, "ReportedValueFloat", 19.5, true},
+		{"FloatFromInteger", `{"reportedValue": 20}`, "ReportedValueFloat", 20.0, true},
+		{"Int", `{"reportedValue": -100}`, "ReportedValueInt", int64(-100), true},
+		{"IntLarge", `{"reportedValue": 9007199254740993}`, "ReportedValueInt", int64(9007199254740993), true},
+		{"IntFromFloat", `{"reportedValue": 19.5}`, "ReportedValueInt", nil, false},
+		{"IntFromString", `{"reportedValue": "100"}`, "ReportedValueInt", nil, false},
+		{"Uint", `{"reportedValue": 100}`, "ReportedValueUint", uint64(100), true},
+		{"UintLarge", `{"reportedValue": 18446744073709551610}`, "ReportedValueUint", uint64(18446744073709551610), true},
+		{"UintNegative", `{"reportedValue": -1}`, "ReportedValueUint", nil, false},
+		{"String", `{"reportedValue": "HEAT"}`, "ReportedValueString", "HEAT", true},
+		{"Bool", `{"reportedValue": true}`, "ReportedValueBool", true, true},
+		{"DisplayInt", `{"displayValue": 80}`, "DisplayValueInt", int64(80), true},
+		{"DisplayUint", `{"displayValue": 80}`, "DisplayValueUint", uint64(80), true},
+		{"DisplayFloat", `{"displayValue": 80.5}`, "DisplayValueFloat", 80.5, true},
+		{"TargetInt", `{"targetValue": 60}`, "TargetValueInt", int64(60), true},
+		{"TargetUint", `{"targetValue": 60}`, "TargetValueUint", uint64(60), true},
+		{"TargetFloat", `{"targetValue": 21}`, "TargetValueFloat", 21.0, true},
+		{"Missing", `{}`, "ReportedValueInt", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attrs nodeAttributes
+			if err := json.Unmarshal([]byte(`{"value": `+tt.json+`}`), &attrs); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+
+			vals := reflect.ValueOf(attrs["value"]).MethodByName(tt.method).Call(nil)
+			got := vals[0].Interface()
+			ok := vals[1].Interface().(bool)
+
+			if ok != tt.wantOk {
+				t.Errorf("nodeAttribute.%v() ok = %v, wantOk = %v", tt.method, ok, tt.wantOk)
+				return
+			}
+
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("nodeAttribute.%v() = %v, want %v", tt.method, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,52 +1,248 @@
 package hive
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/url"
+	"sort"
+	"time"
 )
 
+// Node is a read-only view of any device in the Home, allowing devices
+// without dedicated support to be read through their attributes. The
+// fields hold the values from when the Node was returned, use
+// CurrentName, CurrentParentNodeID and CurrentLastSeen for the values
+// from the latest refresh.
+type Node struct {
+	home *Home
+	node *node
+
+	ID           string
+	Name         string
+	Href         string
+	ParentNodeID string
+	LastSeen     time.Time
+}
+
+func (home *Home) newNode(n *node) *Node {
+	return home.track(&Node{
+		home:         home,
+		node:         n,
+		ID:           n.ID,
+		Name:         n.Name,
+		Href:         n.Href,
+		ParentNodeID: n.ParentNodeID,
+		LastSeen:     millisTime(n.LastSeen),
+	}).(*Node)
+}
+
+// CurrentName returns the name of the Node from the latest refresh
+func (n *Node) CurrentName() string {
+	return n.current().Name
+}
+
+// CurrentParentNodeID returns the ID of the parent of the Node from the
+// latest refresh
+func (n *Node) CurrentParentNodeID() string {
+	return n.current().ParentNodeID
+}
+
+// CurrentLastSeen returns when the Node was last seen as of the latest
+// refresh
+func (n *Node) CurrentLastSeen() time.Time {
+	return millisTime(n.current().LastSeen)
+}
+
+// NodeType returns the schema URL identifying the type of the Node
+func (n *Node) NodeType() (string, error) {
+	return n.current().NodeType()
+}
+
+// Attribute returns the named attribute of the Node
+func (n *Node) Attribute(name string) (*Attribute, bool) {
+	return n.current().attribute(name)
+}
+
+// AttributeNames returns the sorted names of the attributes of the Node
+func (n *Node) AttributeNames() []string {
+	attrs := n.current().Attributes
+
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Nodes returns every node in the Home
+func (home *Home) Nodes() ([]*Node, error) {
+	return home.NodesContext(context.Background())
+}
+
+// NodesContext returns every node in the Home using the provided context.
+func (home *Home) NodesContext(ctx context.Context) ([]*Node, error) {
+	nodes, err := home.nodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Node, 0, len(nodes))
+	for _, n := range nodes {
+		result = append(result, home.newNode(n))
+	}
+
+	return result, nil
+}
+
 type nodesResponse struct {
 	Nodes []*node `json:"nodes,omitempty"`
 }
 
-func (home *Home) nodes() ([]*node, error) {
-	resp, err := home.httpRequestWithSession(http.MethodGet, "/omnia/nodes", nil)
+// decodeNodes decodes the nodes in resp. The returned bool is false when
+// resp has no body to decode, as with a 204 No Content or an empty 201
+// Created response.
+func decodeNodes(op string, resp *http.Response) ([]*node, bool, error) {
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, false, nil
+	}
+
+	body := bufio.NewReader(resp.Body)
+	if _, err := body.Peek(1); err == io.EOF {
+		return nil, false, nil
+	}
+
+	var response nodesResponse
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		return nil, true, &Error{Op: op, Code: ErrInvalidJSON, Err: err}
+	}
+
+	return response.Nodes, true, nil
+}
+
+// emptyResponse is the error returned when a request which must return
+// nodes returns no body
+func emptyResponse(op string, resp *http.Response) error {
+	return &Error{
+		Op:         op,
+		Code:       ErrUnexpectedResponse,
+		Message:    "empty " + resp.Status + " response",
+		StatusCode: resp.StatusCode,
+	}
+}
+
+func (home *Home) nodes(ctx context.Context) ([]*node, error) {
+	resp, err := home.httpRequestWithSession(ctx, http.MethodGet, "/omnia/nodes", nil)
 	if err != nil {
 		return nil, &Error{Op: "node: response", Err: err}
 	}
 
 	defer resp.Body.Close()
 
-	var response nodesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, &Error{Op: "nodes: decode", Code: ErrInvalidJSON, Err: err}
+	nodes, ok, err := decodeNodes("nodes: decode", resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, emptyResponse("nodes", resp)
 	}
 
-	return response.Nodes, nil
+	return nodes, nil
 }
 
-func (home *Home) node(href string) (*node, error) {
+func (home *Home) node(ctx context.Context, href string) (*node, error) {
 	uri, err := url.Parse(href)
 	if err != nil {
 		return nil, &Error{Op: "node: request", Err: err}
 	}
 
-	resp, err := home.httpRequestWithSession(http.MethodGet, uri.RequestURI(), nil)
+	resp, err := home.httpRequestWithSession(ctx, http.MethodGet, uri.RequestURI(), nil)
 	if err != nil {
 		return nil, &Error{Op: "node: response", Err: err}
 	}
 
 	defer resp.Body.Close()
 
-	var response nodesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, &Error{Op: "node: decode", Code: ErrInvalidJSON, Err: err}
+	nodes, ok, err := decodeNodes("node: decode", resp)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(response.Nodes) != 1 {
+	if !ok {
+		return nil, emptyResponse("node", resp)
+	}
+
+	if len(nodes) != 1 {
 		return nil, &Error{Op: "node", Code: ErrNodeNotFound, Message: "incorrect number of nodes returned"}
 	}
 
-	return response.Nodes[0], nil
+	return nodes[0], nil
+}
+
+// setNode sets the target values of the given attributes on the node
+// at href, returning the updated node. The node is fetched again when the
+// update is accepted without a body, as with a 204 No Content response.
+func (home *Home) setNode(ctx context.Context, op, href string, attrs nodeAttributes) (*node, error) {
+	body := &nodesResponse{
+		Nodes: []*node{{
+			Attributes: attrs,
+		}},
+	}
+
+	uri, err := url.Parse(href)
+	if err != nil {
+		return nil, &Error{Op: op, Err: err}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(body); err != nil {
+		return nil, &Error{
+			Op:  op + ": encode json",
+			Err: err,
+		}
+	}
+
+	rs := bytes.NewReader(buf.Bytes()) // convert JSON bytes into bytes.Reader to support io.ReadSeeker
+	resp, err := home.httpRequestWithSession(ctx, http.MethodPut, uri.RequestURI(), rs)
+	if err != nil {
+		return nil, &Error{
+			Op:  op + ": request",
+			Err: err,
+		}
+	}
+
+	defer resp.Body.Close()
+
+	nodes, ok, err := decodeNodes(op+": read body", resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		// the update was accepted without returning the node, so fetch
+		// it to pick up the new target values
+		n, err := home.node(ctx, href)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = []*node{n}
+	}
+
+	if len(nodes) != 1 {
+		return nil, &Error{
+			Op:      op,
+			Code:    ErrNodeNotFound,
+			Message: "incorrect number of nodes returned",
+		}
+	}
+
+	home.requested(nodes[0].ID, attrs)
+	return nodes[0], nil
 }
@@ -1,12 +1,14 @@
 package hive
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestHome_nodes(t *testing.T) {
@@ -91,7 +93,7 @@ func TestHome_nodes(t *testing.T) {
 	home := &Home{
 		baseURL:    baseURL,
 		httpClient: srv.Client(),
-		sessionID:  "4wdz82NrUmdYCuuNz3wzofWGymjRWigL",
+		session:    Session{ID: "4wdz82NrUmdYCuuNz3wzofWGymjRWigL"},
 	}
 
 	tests := []struct {
@@ -158,7 +160,7 @@ func TestHome_nodes(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := home.nodes()
+			got, err := home.nodes(context.Background())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Home.nodes() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -185,6 +187,11 @@ func TestHome_node(t *testing.T) {
 			return
 		}
 
+		if r.URL.Path == "/omnia/nodes/no-content" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
 		if r.URL.Path == "/omnia/nodes/zero-nodes" {
 			w.Header().Set("Content-Type", "application/vnd.alertme.zoo-6.1+json;charset=UTF-8")
 			w.WriteHeader(http.StatusOK)
@@ -272,7 +279,7 @@ func TestHome_node(t *testing.T) {
 	home := &Home{
 		baseURL:    baseURL,
 		httpClient: srv.Client(),
-		sessionID:  "4wdz82NrUmdYCuuNz3wzofWGymjRWigL",
+		session:    Session{ID: "4wdz82NrUmdYCuuNz3wzofWGymjRWigL"},
 	}
 
 	tests := []struct {
@@ -339,10 +346,11 @@ func TestHome_node(t *testing.T) {
 		{"InvalidHref", ":foo", nil, true},
 		{"InvalidJSON", "/omnia/nodes/invalid-json", nil, true},
 		{"ZeroNodes", "/omnia/nodes/zero-nodes", nil, true},
+		{"NoContent", "/omnia/nodes/no-content", nil, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := home.node(tt.href)
+			got, err := home.node(context.Background(), tt.href)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Home.node() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -353,3 +361,104 @@ func TestHome_node(t *testing.T) {
 		})
 	}
 }
+
+func TestHome_Nodes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/omnia/nodes" {
+			http.Error(w, "unknown path", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.alertme.zoo-6.1+json;charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{
+			"nodes": [{
+				"id": "546a661e-78b9-4159-90b6-b14454922f85",
+				"href": "https://api-prod.bgchprod.info/omnia/nodes/546a661e-78b9-4159-90b6-b14454922f85",
+				"name": "Hallway Sensor",
+				"parentNodeId": "79c4c839-1ab7-45a7-abb4-9be3908e75c5",
+				"lastSeen": 1530553614549,
+				"attributes": {
+					"nodeType": {
+						"reportedValue": "http://alertme.com/schema/json/node.class.motion.sensor.json#",
+						"displayValue": "http://alertme.com/schema/json/node.class.motion.sensor.json#",
+						"reportReceivedTime": 1541630012412,
+						"reportChangedTime": 1528575086933
+					},
+					"inMotion": {
+						"reportedValue": true,
+						"displayValue": true,
+						"targetValue": false,
+						"reportReceivedTime": 1541629836583,
+						"reportChangedTime": 1540730985715
+					}
+				}
+			}]
+		}`)
+	}))
+
+	defer srv.Close()
+
+	baseURL, _ := url.Parse(srv.URL)
+	home := &Home{
+		baseURL:    baseURL,
+		httpClient: srv.Client(),
+	}
+
+	nodes, err := home.Nodes()
+	if err != nil {
+		t.Fatalf("Home.Nodes() error = %v, want nil", err)
+	}
+
+	if len(nodes) != 1 {
+		t.Fatalf("Home.Nodes() = %v nodes, want 1", len(nodes))
+	}
+
+	n := nodes[0]
+
+	if n.ID != "546a661e-78b9-4159-90b6-b14454922f85" || n.Name != "Hallway Sensor" ||
+		n.ParentNodeID != "79c4c839-1ab7-45a7-abb4-9be3908e75c5" {
+		t.Errorf("Home.Nodes() node = %+v", n)
+	}
+
+	if want := time.Unix(1530553614, 549000000); !n.LastSeen.Equal(want) {
+		t.Errorf("Node.LastSeen = %v, want %v", n.LastSeen, want)
+	}
+
+	if nt, err := n.NodeType(); err != nil || nt != "http://alertme.com/schema/json/node.class.motion.sensor.json#" {
+		t.Errorf("Node.NodeType() = %v, %v", nt, err)
+	}
+
+	if got, want := n.AttributeNames(), []string{"inMotion", "nodeType"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Node.AttributeNames() = %v, want %v", got, want)
+	}
+
+	if _, ok := n.Attribute("missing"); ok {
+		t.Errorf("Node.Attribute(missing) ok = %v, want false", ok)
+	}
+
+	attr, ok := n.Attribute("inMotion")
+	if !ok {
+		t.Fatalf("Node.Attribute(inMotion) ok = %v, want true", ok)
+	}
+
+	if v, ok := attr.ReportedValueBool(); !ok || !v {
+		t.Errorf("Attribute.ReportedValueBool() = %v, %v, want true, true", v, ok)
+	}
+
+	if v, ok := attr.TargetValueBool(); !ok || v {
+		t.Errorf("Attribute.TargetValueBool() = %v, %v, want false, true", v, ok)
+	}
+
+	if _, ok := attr.DisplayValueString(); ok {
+		t.Errorf("Attribute.DisplayValueString() ok = %v, want false", ok)
+	}
+
+	if want := time.Unix(1541629836, 583000000); !attr.ReportReceived().Equal(want) {
+		t.Errorf("Attribute.ReportReceived() = %v, want %v", attr.ReportReceived(), want)
+	}
+
+	if want := time.Unix(1540730985, 715000000); !attr.ReportChanged().Equal(want) {
+		t.Errorf("Attribute.ReportChanged() = %v, want %v", attr.ReportChanged(), want)
+	}
+}
@@ -13,6 +13,12 @@ type options struct {
 	password   string
 	httpClient *http.Client
 	tlsConfig  *tls.Config
+
+	retryPolicy  RetryPolicy
+	session      Session
+	sessionStore SessionStore
+
+	confirmationInterval time.Duration
 }
 
 var defaultOptions = options{
@@ -61,3 +67,38 @@ func WithHTTPClient(c *http.Client) Option {
 		o.httpClient = c
 	}
 }
+
+// WithRetryPolicy sets how failed requests to the Hive API are retried,
+// by default requests are not retried.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = p
+	}
+}
+
+// WithSession reuses an existing Session rather than logging in when
+// connecting. The credentials are still used to login again once the
+// session expires.
+func WithSession(s Session) Option {
+	return func(o *options) {
+		o.session = s
+	}
+}
+
+// WithSessionStore loads the Session to reuse from the store when
+// connecting, saving each new Session to it after logging in. The store
+// is best-effort, a Session which fails to load is replaced by logging
+// in and a Session which fails to save is still used.
+func WithSessionStore(s SessionStore) Option {
+	return func(o *options) {
+		o.sessionStore = s
+	}
+}
+
+// WithConfirmationInterval sets how often WaitForConfirmation polls the
+// API, by default DefaultConfirmationInterval.
+func WithConfirmationInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.confirmationInterval = d
+	}
+}
@@ -18,6 +18,10 @@ func TestOptions(t *testing.T) {
 		{"WithCredentials", WithCredentials("user", "pass"), options{username: "user", password: "pass"}},
 		{"WithTLSConfig", WithTLSConfig(&tls.Config{InsecureSkipVerify: true}), options{tlsConfig: &tls.Config{InsecureSkipVerify: true}}},
 		{"WithHTTPClient", WithHTTPClient(&http.Client{Timeout: 10 * time.Second}), options{httpClient: &http.Client{Timeout: 10 * time.Second}}},
+		{"WithSession", WithSession(Session{ID: "token"}), options{session: Session{ID: "token"}}},
+		{"WithSessionStore", WithSessionStore(&FileSessionStore{Path: "session.json"}), options{sessionStore: &FileSessionStore{Path: "session.json"}}},
+		{"WithRetryPolicy", WithRetryPolicy(DefaultRetryPolicy), options{retryPolicy: DefaultRetryPolicy}},
+		{"WithConfirmationInterval", WithConfirmationInterval(time.Second), options{confirmationInterval: time.Second}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,182 @@
+package hive
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// DefaultConfirmationInterval is how often WaitForConfirmation polls the
+// API for a device to report its pending changes.
+const DefaultConfirmationInterval = 5 * time.Second
+
+// PendingChange is a requested value of an attribute which the device
+// has not yet reported. The embedded Attribute provides the requested
+// TargetValue and the last confirmed ReportedValue.
+type PendingChange struct {
+	*Attribute
+
+	// RequestedAt is when the change was requested through the Home,
+	// it is zero when the change was requested elsewhere.
+	RequestedAt time.Time
+
+	clk clock
+}
+
+// Age returns how long ago the change was requested, or zero when it
+// was not requested through the Home.
+func (pc *PendingChange) Age() time.Duration {
+	if pc.RequestedAt.IsZero() {
+		return 0
+	}
+
+	clk := pc.clk
+	if clk == nil {
+		clk = realClock{}
+	}
+
+	return clk.Now().Sub(pc.RequestedAt)
+}
+
+type pendingKey struct {
+	nodeID string
+	attr   string
+}
+
+// requested records when each of attrs was requested of the node
+func (home *Home) requested(nodeID string, attrs nodeAttributes) {
+	home.pendingMu.Lock()
+	defer home.pendingMu.Unlock()
+
+	if home.requestedAt == nil {
+		home.requestedAt = make(map[pendingKey]time.Time)
+	}
+
+	now := home.clock().Now()
+	for name := range attrs {
+		home.requestedAt[pendingKey{nodeID, name}] = now
+	}
+}
+
+// pendingChanges returns the changes of n which have not been reported,
+// sorted by attribute name.
+func (home *Home) pendingChanges(n *node) []*PendingChange {
+	names := make([]string, 0, len(n.Attributes))
+	for name := range n.Attributes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var changes []*PendingChange
+	for _, name := range names {
+		if pc, ok := home.pendingChange(n, name); ok {
+			changes = append(changes, pc)
+		}
+	}
+
+	return changes
+}
+
+// pendingChange returns the change pending for the named attribute of n
+func (home *Home) pendingChange(n *node, name string) (*PendingChange, bool) {
+	key := pendingKey{n.ID, name}
+
+	attr, ok := n.Attributes[name]
+	if !ok || attr.TargetValue == nil || sameValue(attr.TargetValue, attr.ReportedValue) {
+		if home != nil {
+			home.pendingMu.Lock()
+			delete(home.requestedAt, key)
+			home.pendingMu.Unlock()
+		}
+
+		return nil, false
+	}
+
+	pc := &PendingChange{Attribute: &Attribute{Name: name, attr: attr}, clk: home.clock()}
+
+	if home != nil {
+		home.pendingMu.Lock()
+		pc.RequestedAt = home.requestedAt[key]
+		home.pendingMu.Unlock()
+	}
+
+	return pc, true
+}
+
+// sameValue reports whether two attribute values are equal, comparing
+// numbers by value regardless of their representation.
+func sameValue(a, b interface{}) bool {
+	var na nodeAttribute
+
+	if fa, ok := na.float64(a); ok {
+		fb, ok := na.float64(b)
+		return ok && fa == fb
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// waitForConfirmation polls with update until current has no pending
+// changes or ctx is done.
+func (home *Home) waitForConfirmation(ctx context.Context, op string, current func() *node, update func(context.Context) error) error {
+	interval := home.confirmationInterval
+	if interval <= 0 {
+		interval = DefaultConfirmationInterval
+	}
+
+	for len(home.pendingChanges(current())) > 0 {
+		if err := sleep(ctx, interval); err != nil {
+			return &Error{Op: op, Err: err}
+		}
+
+		if err := update(ctx); err != nil {
+			return &Error{Op: op, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// Pending returns the change pending for the named attribute of the
+// Thermostat, if any.
+func (t *Thermostat) Pending(name string) (*PendingChange, bool) {
+	return t.home.pendingChange(t.current(), name)
+}
+
+// PendingChanges returns every change requested of the Thermostat which
+// it has not yet reported.
+func (t *Thermostat) PendingChanges() []*PendingChange {
+	return t.home.pendingChanges(t.current())
+}
+
+// WaitForConfirmation polls the API until the Thermostat reports all of
+// its pending changes, or the context is done.
+func (t *Thermostat) WaitForConfirmation(ctx context.Context) error {
+	return t.home.waitForConfirmation(ctx, "thermostat: wait for confirmation", t.current, t.UpdateContext)
+}
+
+// Pending returns the change pending for the named attribute of the
+// HotWater, if any.
+func (hw *HotWater) Pending(name string) (*PendingChange, bool) {
+	return hw.home.pendingChange(hw.current(), name)
+}
+
+// PendingChanges returns every change requested of the HotWater which
+// it has not yet reported.
+func (hw *HotWater) PendingChanges() []*PendingChange {
+	return hw.home.pendingChanges(hw.current())
+}
+
+// WaitForConfirmation polls the API until the HotWater reports all of
+// its pending changes, or the context is done.
+func (hw *HotWater) WaitForConfirmation(ctx context.Context) error {
+	return hw.home.waitForConfirmation(ctx, "hot water: wait for confirmation", hw.current, hw.UpdateContext)
+}
+
+// PendingChanges returns every change requested of the Node which it has
+// not yet reported.
+func (n *Node) PendingChanges() []*PendingChange {
+	return n.home.pendingChanges(n.current())
+}
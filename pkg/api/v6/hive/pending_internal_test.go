@@ -0,0 +1,34 @@
+package hive
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPendingChange_Age(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(1600000000, 0)}
+	home := &Home{clk: clk}
+
+	n := &node{
+		ID: "thermostat",
+		Attributes: nodeAttributes{
+			"targetHeatTemperature": {ReportedValue: 20.0, TargetValue: 21.0},
+		},
+	}
+
+	home.requested(n.ID, nodeAttributes{"targetHeatTemperature": {TargetValue: 21.0}})
+	clk.now = clk.now.Add(90 * time.Second)
+
+	pc, ok := home.pendingChange(n, "targetHeatTemperature")
+	if !ok {
+		t.Fatalf("Home.pendingChange() ok = false, want true")
+	}
+
+	if !pc.RequestedAt.Equal(time.Unix(1600000000, 0)) {
+		t.Errorf("PendingChange.RequestedAt = %v, want the Home clock time", pc.RequestedAt)
+	}
+
+	if got := pc.Age(); got != 90*time.Second {
+		t.Errorf("PendingChange.Age() = %v, want %v", got, 90*time.Second)
+	}
+}
@@ -0,0 +1,84 @@
+package hive_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/geoffgarside/homekit-hive/pkg/api/v6/hive"
+	"github.com/geoffgarside/homekit-hive/pkg/api/v6/hive/hivetest"
+)
+
+func TestThermostat_PendingChanges(t *testing.T) {
+	srv := hivetest.NewServer("username", "password")
+	defer srv.Close()
+
+	srv.AddNode(hivetest.Thermostat("t1", "Heating", 19.5, 20))
+	srv.SetPending(true)
+
+	home, err := hive.Connect(
+		hive.WithCredentials("username", "password"),
+		hive.WithHTTPClient(srv.Client()),
+		hive.WithURL(srv.URL),
+		hive.WithConfirmationInterval(10*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("hive.Connect() error = %v, want nil", err)
+	}
+
+	thermostats, err := home.Thermostats()
+	if err != nil || len(thermostats) != 1 {
+		t.Fatalf("Home.Thermostats() = %v, %v, want 1 thermostat", thermostats, err)
+	}
+
+	ts := thermostats[0]
+
+	if got := ts.PendingChanges(); len(got) != 0 {
+		t.Errorf("Thermostat.PendingChanges() = %v, want none", got)
+	}
+
+	if err := ts.SetTarget(21); err != nil {
+		t.Fatalf("Thermostat.SetTarget() error = %v, want nil", err)
+	}
+
+	pc, ok := ts.Pending("targetHeatTemperature")
+	if !ok {
+		t.Fatalf("Thermostat.Pending() ok = false, want true")
+	}
+
+	if v, _ := pc.TargetValueFloat(); v != 21 {
+		t.Errorf("PendingChange.TargetValueFloat() = %v, want 21", v)
+	}
+
+	if v, _ := pc.ReportedValueFloat(); v != 20 {
+		t.Errorf("PendingChange.ReportedValueFloat() = %v, want 20", v)
+	}
+
+	if pc.RequestedAt.IsZero() || pc.Age() < 0 {
+		t.Errorf("PendingChange.RequestedAt = %v, want request time", pc.RequestedAt)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := ts.WaitForConfirmation(ctx); err == nil {
+		t.Errorf("Thermostat.WaitForConfirmation() error = %v, want timeout", err)
+	}
+
+	time.AfterFunc(30*time.Millisecond, srv.Settle)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := ts.WaitForConfirmation(ctx); err != nil {
+		t.Fatalf("Thermostat.WaitForConfirmation() error = %v, want nil", err)
+	}
+
+	if got := ts.PendingChanges(); len(got) != 0 {
+		t.Errorf("Thermostat.PendingChanges() = %v, want none once confirmed", got)
+	}
+
+	if got, _ := ts.Target(); got != 21 {
+		t.Errorf("Thermostat.Target() = %v, want 21", got)
+	}
+}
@@ -0,0 +1,177 @@
+package hive
+
+import (
+	"context"
+	"reflect"
+	"sort"
+)
+
+// device is implemented by every type the Home hands out which wraps a
+// node, allowing Refresh to update them in place.
+type device interface {
+	deviceID() string
+	deviceHref() string
+	refresh(n *node)
+}
+
+// Device is any Thermostat, Controller, HotWater or Node handed out by
+// the Home.
+type Device interface {
+	device
+}
+
+type deviceKey struct {
+	kind reflect.Type
+	id   string
+}
+
+func (t *Thermostat) deviceID() string { return t.ID }
+func (c *Controller) deviceID() string { return c.ID }
+func (hw *HotWater) deviceID() string  { return hw.ID }
+func (n *Node) deviceID() string       { return n.ID }
+
+func (t *Thermostat) deviceHref() string { return t.Href }
+func (c *Controller) deviceHref() string { return c.Href }
+func (hw *HotWater) deviceHref() string  { return hw.Href }
+func (n *Node) deviceHref() string       { return n.Href }
+
+func (t *Thermostat) refresh(n *node) { t.home.storeNode(&t.node, n) }
+func (c *Controller) refresh(n *node) { c.home.storeNode(&c.node, n) }
+func (hw *HotWater) refresh(n *node)  { hw.home.storeNode(&hw.node, n) }
+func (n *Node) refresh(nn *node)      { n.home.storeNode(&n.node, nn) }
+
+func (t *Thermostat) current() *node { return t.home.loadNode(&t.node) }
+func (c *Controller) current() *node { return c.home.loadNode(&c.node) }
+func (hw *HotWater) current() *node  { return hw.home.loadNode(&hw.node) }
+func (n *Node) current() *node       { return n.home.loadNode(&n.node) }
+
+// loadNode returns the node at p, synchronised with storeNode so device
+// values may be read while another goroutine updates them.
+func (home *Home) loadNode(p **node) *node {
+	if home == nil {
+		return *p
+	}
+
+	home.nodeMu.RLock()
+	defer home.nodeMu.RUnlock()
+
+	return *p
+}
+
+// storeNode replaces the node at p
+func (home *Home) storeNode(p **node, n *node) {
+	if home == nil {
+		*p = n
+		return
+	}
+
+	home.nodeMu.Lock()
+	defer home.nodeMu.Unlock()
+
+	*p = n
+}
+
+// track records d as handed out by the Home. If an equivalent device has
+// already been handed out it is refreshed with the node of d and
+// returned instead, so each device is represented by a single value.
+func (home *Home) track(d device) device {
+	home.devicesMu.Lock()
+	defer home.devicesMu.Unlock()
+
+	if home.devices == nil {
+		home.devices = make(map[deviceKey]device)
+	}
+
+	key := deviceKey{kind: reflect.TypeOf(d), id: d.deviceID()}
+	if existing, ok := home.devices[key]; ok {
+		delete(home.missing, key)
+		existing.refresh(nodeOf(d))
+		return existing
+	}
+
+	home.devices[key] = d
+	return d
+}
+
+// nodeOf returns the node wrapped by d
+func nodeOf(d device) *node {
+	switch v := d.(type) {
+	case *Thermostat:
+		return v.node
+	case *Controller:
+		return v.node
+	case *HotWater:
+		return v.node
+	case *Node:
+		return v.node
+	}
+
+	return nil
+}
+
+// Refresh fetches every node in the Home with a single request and
+// updates every Thermostat, Controller, HotWater and Node handed out by
+// the Home in place.
+func (home *Home) Refresh() error {
+	return home.RefreshContext(context.Background())
+}
+
+// RefreshContext fetches every node in the Home with a single request
+// using the provided context and updates every device handed out by the
+// Home in place. Devices missing from the response are left unchanged
+// until their node is reported again, which callers can check with
+// Tracking.
+func (home *Home) RefreshContext(ctx context.Context) error {
+	nodes, err := home.nodes(ctx)
+	if err != nil {
+		return &Error{Op: "home: refresh", Err: err}
+	}
+
+	home.refreshDevices(nodes)
+	return nil
+}
+
+// Tracking reports whether d is refreshed by the Home, it is false while
+// the node of d is missing from the latest refresh and true again once
+// the node is reported again.
+func (home *Home) Tracking(d Device) bool {
+	home.devicesMu.Lock()
+	defer home.devicesMu.Unlock()
+
+	key := deviceKey{kind: reflect.TypeOf(d), id: d.deviceID()}
+	existing, ok := home.devices[key]
+	return ok && existing == d && !home.missing[key]
+}
+
+// refreshDevices updates every device handed out by the Home from nodes,
+// marking and returning the sorted IDs of any devices missing from nodes.
+func (home *Home) refreshDevices(nodes []*node) []string {
+	byID := make(map[string]*node, len(nodes))
+	for _, n := range nodes {
+		byID[n.ID] = n
+	}
+
+	home.devicesMu.Lock()
+	defer home.devicesMu.Unlock()
+
+	var missing []string
+
+	for key, d := range home.devices {
+		n, ok := byID[key.id]
+		if !ok {
+			if home.missing == nil {
+				home.missing = make(map[deviceKey]bool)
+			}
+
+			home.missing[key] = true
+			missing = append(missing, key.id)
+			continue
+		}
+
+		delete(home.missing, key)
+		d.refresh(n)
+	}
+
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,156 @@
+package hive
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestHome_Refresh(t *testing.T) {
+	var (
+		requests int
+		name     = "Receiver 1"
+		temp     = 17.5
+		battery  = 100.0
+		withUI   = true
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/omnia/nodes" {
+			http.Error(w, "unknown path", http.StatusNotFound)
+			return
+		}
+
+		requests++
+
+		ui := ""
+		if withUI {
+			ui = fmt.Sprintf(`, {
+				"id": "ui",
+				"name": "Thermostat",
+				"attributes": {
+					"nodeType": {"reportedValue": "http://alertme.com/schema/json/node.class.thermostatui.json#"},
+					"batteryLevel": {"reportedValue": %v}
+				}
+			}`, battery)
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.alertme.zoo-6.1+json;charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{
+			"nodes": [{
+				"id": "heating",
+				"name": %q,
+				"lastSeen": 1530553614549,
+				"attributes": {
+					"nodeType": {"reportedValue": "http://alertme.com/schema/json/node.class.thermostat.json#"},
+					"temperature": {"reportedValue": %v}
+				}
+			}%s]
+		}`, name, temp, ui)
+	}))
+
+	defer srv.Close()
+
+	baseURL, _ := url.Parse(srv.URL)
+	home := &Home{baseURL: baseURL, httpClient: srv.Client()}
+
+	thermostats, err := home.Thermostats()
+	if err != nil || len(thermostats) != 1 {
+		t.Fatalf("Home.Thermostats() = %v, %v, want one thermostat", thermostats, err)
+	}
+
+	controllers, err := home.Controllers()
+	if err != nil || len(controllers) != 1 {
+		t.Fatalf("Home.Controllers() = %v, %v, want one controller", controllers, err)
+	}
+
+	nodes, err := home.Nodes()
+	if err != nil || len(nodes) != 2 {
+		t.Fatalf("Home.Nodes() = %v, %v, want two nodes", nodes, err)
+	}
+
+	again, err := home.Thermostats()
+	if err != nil || len(again) != 1 || again[0] != thermostats[0] {
+		t.Errorf("Home.Thermostats() = %v, want the thermostat already handed out", again)
+	}
+
+	name, temp, battery, requests = "Heating", 21.0, 80.0, 0
+
+	if err := home.Refresh(); err != nil {
+		t.Fatalf("Home.Refresh() error = %v, want nil", err)
+	}
+
+	if requests != 1 {
+		t.Errorf("Home.Refresh() requests = %v, want 1", requests)
+	}
+
+	if got, _ := thermostats[0].Temperature(); got != temp {
+		t.Errorf("Thermostat.Temperature() = %v, want %v", got, temp)
+	}
+
+	if got, _ := controllers[0].BatteryLevel(); got != int(battery) {
+		t.Errorf("Controller.BatteryLevel() = %v, want %v", got, battery)
+	}
+
+	for _, n := range nodes {
+		if n.ID != "heating" {
+			continue
+		}
+
+		attr, _ := n.Attribute("temperature")
+		if got, _ := attr.ReportedValueFloat(); got != temp {
+			t.Errorf("Node.Attribute(temperature) = %v, want %v", got, temp)
+		}
+
+		if n.Name != "Receiver 1" || n.CurrentName() != name {
+			t.Errorf("Node.Name, CurrentName() = %q, %q, want %q, %q", n.Name, n.CurrentName(), "Receiver 1", name)
+		}
+	}
+
+	withUI = false
+
+	temp = 22.0
+
+	if err := home.Refresh(); err != nil {
+		t.Errorf("Home.Refresh() error = %v, want nil", err)
+	}
+
+	if got, _ := thermostats[0].Temperature(); got != temp {
+		t.Errorf("Thermostat.Temperature() = %v, want %v", got, temp)
+	}
+
+	if got, _ := controllers[0].BatteryLevel(); got != int(battery) {
+		t.Errorf("Controller.BatteryLevel() after missing = %v, want %v", got, battery)
+	}
+
+	if home.Tracking(controllers[0]) {
+		t.Errorf("Home.Tracking(controller) = true, want false once missing")
+	}
+
+	if !home.Tracking(thermostats[0]) {
+		t.Errorf("Home.Tracking(thermostat) = false, want true")
+	}
+
+	withUI, battery = true, 60.0
+
+	if err := home.Refresh(); err != nil {
+		t.Errorf("Home.Refresh() error = %v, want nil", err)
+	}
+
+	if !home.Tracking(controllers[0]) {
+		t.Errorf("Home.Tracking(controller) = false, want true once reported again")
+	}
+
+	if got, _ := controllers[0].BatteryLevel(); got != int(battery) {
+		t.Errorf("Controller.BatteryLevel() after reappearing = %v, want %v", got, battery)
+	}
+
+	requests = 0
+
+	if err := home.Refresh(); err != nil || requests != 1 {
+		t.Errorf("Home.Refresh() = %v with %v requests, want nil with 1", err, requests)
+	}
+}
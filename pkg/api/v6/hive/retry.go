@@ -0,0 +1,162 @@
+package hive
+
+import (
+	"context"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how failed requests to the Hive API are retried.
+// Requests are retried with exponential backoff and jitter, honouring any
+// Retry-After header sent by the API. GET requests are retried after
+// network errors and server errors, other requests are only retried when
+// the API reports it did not process them, with a 429 or 503 status.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made, including the
+	// first. Values less than 2 disable retries.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry, doubling for each
+	// subsequent retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between attempts, zero leaves it uncapped.
+	// Requests are not retried when the API asks for a longer delay with
+	// Retry-After.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is a RetryPolicy suitable for long running clients
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// backoff returns the jittered delay before the given retry, starting at 1
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < retry && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		if d > math.MaxInt64/2 {
+			d = math.MaxInt64
+			break
+		}
+
+		d *= 2
+	}
+
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+
+	if d <= 0 {
+		return 0
+	}
+
+	// Wait between half and all of the delay so concurrent clients
+	// spread out their retries.
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// delay returns how long to wait before retrying after the given attempt
+// and whether the request should be retried at all.
+func (p RetryPolicy) delay(attempt int, method string, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	if !retryable(method, resp, err) {
+		return 0, false
+	}
+
+	d := p.backoff(attempt)
+
+	if resp != nil {
+		if after, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxDelay > 0 && after > p.MaxDelay {
+				return 0, false
+			}
+
+			if after > d {
+				d = after
+			}
+		}
+	}
+
+	return d, true
+}
+
+// retryable reports whether a request may be retried after receiving
+// resp or err. Only idempotent methods are retried when the request may
+// have been processed by the API.
+func retryable(method string, resp *http.Response, err error) bool {
+	idempotent := method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+
+	if err != nil {
+		return idempotent
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+
+	return false
+}
+
+// retryAfter parses a Retry-After header as either seconds or a HTTP date
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+
+		return d, true
+	}
+
+	return 0, false
+}
+
+// rewind prepares body to be sent again, returning false if it cannot be
+func rewind(body io.Reader) bool {
+	if body == nil {
+		return true
+	}
+
+	s, ok := body.(io.Seeker)
+	if !ok {
+		return false
+	}
+
+	_, err := s.Seek(0, io.SeekStart)
+	return err == nil
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,213 @@
+package hive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 10, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		retry    int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{5, 500 * time.Millisecond, time.Second},
+		{9, 500 * time.Millisecond, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.retry), func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				if got := p.backoff(tt.retry); got < tt.min || got > tt.max {
+					t.Fatalf("RetryPolicy.backoff(%v) = %v, want between %v and %v", tt.retry, got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_backoff_noMaxDelay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond}
+
+	tests := []struct {
+		retry    int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{4, 400 * time.Millisecond, 800 * time.Millisecond},
+		{100, math.MaxInt64 / 2, math.MaxInt64},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.retry), func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				if got := p.backoff(tt.retry); got < tt.min || got > tt.max {
+					t.Fatalf("RetryPolicy.backoff(%v) = %v, want between %v and %v", tt.retry, got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func Test_retryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{"Empty", "", 0, false},
+		{"Seconds", "3", 3 * time.Second, true},
+		{"Past", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+		{"Invalid", "soon", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := retryAfter(tt.value)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("retryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestHome_httpRequest_retry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+
+	tests := []struct {
+		name         string
+		method       string
+		policy       RetryPolicy
+		statuses     []int
+		retryAfter   string
+		wantAttempts int
+		wantErr      bool
+	}{
+		{"GetRecovers", http.MethodGet, policy, []int{502, 504, 200}, "", 3, false},
+		{"GetExhausted", http.MethodGet, policy, []int{503, 503, 503, 200}, "", 3, true},
+		{"GetClientError", http.MethodGet, policy, []int{400, 200}, "", 1, true},
+		{"PutServerError", http.MethodPut, policy, []int{502, 200}, "", 1, true},
+		{"PutRateLimited", http.MethodPut, policy, []int{429, 200}, "", 2, false},
+		{"PostUnavailable", http.MethodPost, policy, []int{503, 200}, "", 2, false},
+		{"RetryAfterTooLong", http.MethodGet, policy, []int{429, 200}, "60", 1, true},
+		{"Disabled", http.MethodGet, RetryPolicy{}, []int{502, 200}, "", 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tt.statuses[attempts]
+				attempts++
+
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+
+				w.Header().Set("Content-Type", "application/vnd.alertme.zoo-6.1+json;charset=UTF-8")
+				w.WriteHeader(status)
+				fmt.Fprint(w, `{"errors":[{"code":"FLAKY","title":"flaky server"}]}`)
+			}))
+
+			defer srv.Close()
+
+			baseURL, _ := url.Parse(srv.URL)
+			home := &Home{baseURL: baseURL, httpClient: srv.Client(), retryPolicy: tt.policy}
+
+			var body io.Reader
+			if tt.method != http.MethodGet {
+				body = strings.NewReader(`{"nodes":[]}`)
+			}
+
+			_, err := home.httpRequest(context.Background(), tt.method, "/omnia/nodes/flaky", body)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Home.httpRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("Home.httpRequest() attempts = %v, want %v", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestHome_httpRequest_retryRewindsBody(t *testing.T) {
+	var bodies []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("ioutil.ReadAll(r.Body) err = %v, want nil", err)
+		}
+		bodies = append(bodies, string(b))
+
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer srv.Close()
+
+	baseURL, _ := url.Parse(srv.URL)
+	home := &Home{
+		baseURL:     baseURL,
+		httpClient:  srv.Client(),
+		retryPolicy: RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond},
+	}
+
+	resp, err := home.httpRequest(context.Background(), http.MethodPut, "/omnia/nodes/x", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("Home.httpRequest() error = %v, want nil", err)
+	}
+	resp.Body.Close()
+
+	if len(bodies) != 2 || bodies[0] != "payload" || bodies[1] != "payload" {
+		t.Errorf("Home.httpRequest() bodies = %q, want payload twice", bodies)
+	}
+}
+
+func TestHome_httpRequest_retryCancelled(t *testing.T) {
+	var attempts int
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	defer srv.Close()
+
+	baseURL, _ := url.Parse(srv.URL)
+	home := &Home{
+		baseURL:     baseURL,
+		httpClient:  srv.Client(),
+		retryPolicy: RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second},
+	}
+
+	if _, err := home.httpRequest(ctx, http.MethodGet, "/omnia/nodes", nil); err == nil {
+		t.Errorf("Home.httpRequest() error = %v, want != nil", err)
+	}
+
+	if attempts != 1 {
+		t.Errorf("Home.httpRequest() attempts = %v, want 1", attempts)
+	}
+}
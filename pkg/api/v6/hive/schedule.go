@@ -0,0 +1,155 @@
+package hive
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// ScheduleMaxEvents is the maximum number of events Hive allows per day
+	ScheduleMaxEvents = 6
+
+	// scheduleTimeLayout is the layout of ScheduleEvent.Time
+	scheduleTimeLayout = "15:04"
+)
+
+// ScheduleEvent is a change of target temperature at a time of day
+type ScheduleEvent struct {
+	// Time of day in 24 hour "15:04" format
+	Time string `json:"time"`
+
+	// Target temperature from Time until the next event
+	Target float64 `json:"targetHeatTemperature"`
+}
+
+// Schedule is the weekly heating schedule of a Thermostat
+type Schedule struct {
+	Monday    []ScheduleEvent `json:"monday"`
+	Tuesday   []ScheduleEvent `json:"tuesday"`
+	Wednesday []ScheduleEvent `json:"wednesday"`
+	Thursday  []ScheduleEvent `json:"thursday"`
+	Friday    []ScheduleEvent `json:"friday"`
+	Saturday  []ScheduleEvent `json:"saturday"`
+	Sunday    []ScheduleEvent `json:"sunday"`
+}
+
+// Day returns the events scheduled for the given day of the week
+func (s *Schedule) Day(d time.Weekday) []ScheduleEvent {
+	return *s.day(d)
+}
+
+// SetDay replaces the events scheduled for the given day of the week
+func (s *Schedule) SetDay(d time.Weekday, events []ScheduleEvent) {
+	*s.day(d) = events
+}
+
+func (s *Schedule) day(d time.Weekday) *[]ScheduleEvent {
+	switch d {
+	case time.Monday:
+		return &s.Monday
+	case time.Tuesday:
+		return &s.Tuesday
+	case time.Wednesday:
+		return &s.Wednesday
+	case time.Thursday:
+		return &s.Thursday
+	case time.Friday:
+		return &s.Friday
+	case time.Saturday:
+		return &s.Saturday
+	default:
+		return &s.Sunday
+	}
+}
+
+// validate checks the schedule against the limits Hive enforces, with
+// every target temperature between min and max inclusive.
+func (s *Schedule) validate(min, max float64) error {
+	const op = "schedule: validate"
+
+	if s == nil {
+		return &Error{Op: op, Code: ErrInvalidSchedule, Message: "schedule is nil"}
+	}
+
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		events := s.Day(d)
+
+		if len(events) == 0 || len(events) > ScheduleMaxEvents {
+			return &Error{
+				Op:      op,
+				Code:    ErrInvalidSchedule,
+				Message: fmt.Sprintf("%v has %d events, must have between 1 and %d", d, len(events), ScheduleMaxEvents),
+			}
+		}
+
+		var prev time.Time
+		for i, e := range events {
+			at, err := time.Parse(scheduleTimeLayout, e.Time)
+			if err != nil {
+				return &Error{
+					Op:      op,
+					Code:    ErrInvalidSchedule,
+					Message: fmt.Sprintf("%v event %d has invalid time %q", d, i, e.Time),
+				}
+			}
+
+			if i > 0 && !at.After(prev) {
+				return &Error{
+					Op:      op,
+					Code:    ErrInvalidSchedule,
+					Message: fmt.Sprintf("%v event %d at %v is not after the previous event", d, i, e.Time),
+				}
+			}
+
+			if e.Target < min || e.Target > max {
+				return &Error{
+					Op:      op,
+					Code:    ErrInvalidSchedule,
+					Message: fmt.Sprintf("%v event %d target %v outside of %v to %v", d, i, e.Target, min, max),
+				}
+			}
+
+			prev = at
+		}
+	}
+
+	return nil
+}
+
+// Schedule returns the weekly heating schedule of the Thermostat
+func (t *Thermostat) Schedule() (*Schedule, error) {
+	attr := t.current().attr("schedule")
+	if attr.ReportedValue == nil {
+		return nil, &Error{
+			Op:      "thermostat: schedule",
+			Code:    ErrInvalidDataType,
+			Message: "schedule attribute missing",
+		}
+	}
+
+	var s Schedule
+	if err := attr.decodeReportedValue(&s); err != nil {
+		return nil, &Error{Op: "thermostat: schedule: decode", Code: ErrInvalidJSON, Err: err}
+	}
+
+	return &s, nil
+}
+
+// SetSchedule replaces the weekly heating schedule of the Thermostat
+func (t *Thermostat) SetSchedule(s *Schedule) error {
+	return t.SetScheduleContext(context.Background(), s)
+}
+
+// SetScheduleContext replaces the weekly heating schedule of the
+// Thermostat using the provided context. The schedule is validated
+// against the limits of the Thermostat before being sent.
+func (t *Thermostat) SetScheduleContext(ctx context.Context, s *Schedule) error {
+	if err := s.validate(t.Minimum(), t.Maximum()); err != nil {
+		return &Error{Op: "thermostat: set schedule", Err: err}
+	}
+
+	return t.home.setAttributes(ctx, "thermostat: set schedule", t, nodeAttributes{
+		"schedule": {TargetValue: s},
+	})
+}
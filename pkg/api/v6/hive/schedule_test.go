@@ -0,0 +1,188 @@
+package hive
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+const scheduleNodeJSON = `{
+	"id": "fe49e95e-c8cc-47cc-b38f-ec0c06361e13",
+	"href": "https://api-prod.bgchprod.info/omnia/nodes/fe49e95e-c8cc-47cc-b38f-ec0c06361e13",
+	"name": "Receiver 1",
+	"attributes": {
+		"minHeatTemperature": {
+			"reportedValue": 5.0,
+			"displayValue": 5.0
+		},
+		"maxHeatTemperature": {
+			"reportedValue": 32.0,
+			"displayValue": 32.0
+		},
+		"schedule": {
+			"reportedValue": {
+				"monday": [
+					{"time": "06:30", "targetHeatTemperature": 20.0},
+					{"time": "08:30", "targetHeatTemperature": 16.0},
+					{"time": "17:00", "targetHeatTemperature": 20.5},
+					{"time": "22:30", "targetHeatTemperature": 16.0}
+				],
+				"tuesday": [{"time": "06:30", "targetHeatTemperature": 20.0}],
+				"wednesday": [{"time": "06:30", "targetHeatTemperature": 20.0}],
+				"thursday": [{"time": "06:30", "targetHeatTemperature": 20.0}],
+				"friday": [{"time": "06:30", "targetHeatTemperature": 20.0}],
+				"saturday": [
+					{"time": "08:00", "targetHeatTemperature": 19.0},
+					{"time": "23:00", "targetHeatTemperature": 16.0}
+				],
+				"sunday": [{"time": "08:00", "targetHeatTemperature": 19.0}]
+			},
+			"reportReceivedTime": 1541629836583,
+			"reportChangedTime": 1528575087449
+		}
+	}
+}`
+
+func scheduleThermostat(t *testing.T) *Thermostat {
+	var n node
+	if err := json.Unmarshal([]byte(scheduleNodeJSON), &n); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	return &Thermostat{ID: n.ID, Name: n.Name, Href: n.Href, node: &n}
+}
+
+func TestThermostat_Schedule(t *testing.T) {
+	ts := scheduleThermostat(t)
+
+	got, err := ts.Schedule()
+	if err != nil {
+		t.Fatalf("Thermostat.Schedule() error = %v, want nil", err)
+	}
+
+	weekday := []ScheduleEvent{{Time: "06:30", Target: 20}}
+	want := &Schedule{
+		Monday: []ScheduleEvent{
+			{Time: "06:30", Target: 20},
+			{Time: "08:30", Target: 16},
+			{Time: "17:00", Target: 20.5},
+			{Time: "22:30", Target: 16},
+		},
+		Tuesday:   weekday,
+		Wednesday: weekday,
+		Thursday:  weekday,
+		Friday:    weekday,
+		Saturday: []ScheduleEvent{
+			{Time: "08:00", Target: 19},
+			{Time: "23:00", Target: 16},
+		},
+		Sunday: []ScheduleEvent{{Time: "08:00", Target: 19}},
+	}
+
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Thermostat.Schedule() = %v, want %v, diff = %v", got, want, diff)
+	}
+
+	ts.node.Attributes = nodeAttributes{}
+	if _, err := ts.Schedule(); err == nil {
+		t.Errorf("Thermostat.Schedule() missing error = %v, want != nil", err)
+	}
+}
+
+func TestThermostat_SetSchedule(t *testing.T) {
+	ts := scheduleThermostat(t)
+
+	var last nodeAttributes
+	srv := echoNodeServer(t, ts.ID, &last)
+	defer srv.Close()
+
+	baseURL, _ := url.Parse(srv.URL)
+	ts.home = &Home{baseURL: baseURL, httpClient: srv.Client()}
+
+	s, err := ts.Schedule()
+	if err != nil {
+		t.Fatalf("Thermostat.Schedule() error = %v, want nil", err)
+	}
+
+	s.SetDay(time.Sunday, []ScheduleEvent{
+		{Time: "09:00", Target: 21},
+		{Time: "22:00", Target: 15},
+	})
+
+	if err := ts.SetSchedule(s); err != nil {
+		t.Fatalf("Thermostat.SetSchedule() error = %v, want nil", err)
+	}
+
+	got, err := ts.Schedule()
+	if err != nil {
+		t.Fatalf("Thermostat.Schedule() after set error = %v, want nil", err)
+	}
+
+	if diff := deep.Equal(got, s); diff != nil {
+		t.Errorf("Thermostat.Schedule() = %v, want %v, diff = %v", got, s, diff)
+	}
+
+	last = nil
+	s.SetDay(time.Monday, nil)
+
+	if err := ts.SetSchedule(s); ErrorCode(err) != ErrInvalidSchedule {
+		t.Errorf("Thermostat.SetSchedule() error = %v, want %v", err, ErrInvalidSchedule)
+	}
+
+	if last != nil {
+		t.Errorf("Thermostat.SetSchedule() request = %v, want none for invalid schedule", last)
+	}
+
+	if err := ts.SetSchedule(nil); ErrorCode(err) != ErrInvalidSchedule {
+		t.Errorf("Thermostat.SetSchedule(nil) error = %v, want %v", err, ErrInvalidSchedule)
+	}
+
+	if last != nil {
+		t.Errorf("Thermostat.SetSchedule(nil) request = %v, want none", last)
+	}
+}
+
+func TestSchedule_validate(t *testing.T) {
+	valid := []ScheduleEvent{{Time: "06:30", Target: 20}, {Time: "22:00", Target: 16}}
+
+	tests := []struct {
+		name    string
+		events  []ScheduleEvent
+		wantErr bool
+	}{
+		{"Valid", valid, false},
+		{"Empty", nil, true},
+		{"TooMany", []ScheduleEvent{
+			{Time: "01:00", Target: 16},
+			{Time: "02:00", Target: 16},
+			{Time: "03:00", Target: 16},
+			{Time: "04:00", Target: 16},
+			{Time: "05:00", Target: 16},
+			{Time: "06:00", Target: 16},
+			{Time: "07:00", Target: 16},
+		}, true},
+		{"InvalidTime", []ScheduleEvent{{Time: "25:00", Target: 16}}, true},
+		{"OutOfOrder", []ScheduleEvent{{Time: "22:00", Target: 16}, {Time: "06:30", Target: 20}}, true},
+		{"Duplicate", []ScheduleEvent{{Time: "06:30", Target: 16}, {Time: "06:30", Target: 20}}, true},
+		{"BelowMinimum", []ScheduleEvent{{Time: "06:30", Target: 4.5}}, true},
+		{"AboveMaximum", []ScheduleEvent{{Time: "06:30", Target: 32.5}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Schedule{}
+			for d := time.Sunday; d <= time.Saturday; d++ {
+				s.SetDay(d, valid)
+			}
+
+			s.SetDay(time.Wednesday, tt.events)
+
+			err := s.validate(5, 32)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Schedule.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package hive
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Session is an authenticated session with the Hive API, it can be
+// persisted and passed to WithSession to avoid logging in again.
+type Session struct {
+	ID         string    `json:"id"`
+	Username   string    `json:"username"`
+	UserID     string    `json:"userId"`
+	APIVersion string    `json:"apiVersion"`
+	IssuedAt   time.Time `json:"issuedAt"`
+}
+
+// SessionStore persists a Session between connections to the Hive API
+type SessionStore interface {
+	// Load returns the stored Session, or the zero Session if none has
+	// been stored.
+	Load() (Session, error)
+
+	// Save replaces the stored Session
+	Save(Session) error
+}
+
+// FileSessionStore is a SessionStore persisting the Session as JSON in
+// the file at Path.
+type FileSessionStore struct {
+	Path string
+}
+
+// Load reads the Session from the file, returning the zero Session if
+// the file does not exist.
+func (f *FileSessionStore) Load() (Session, error) {
+	var s Session
+
+	b, err := ioutil.ReadFile(f.Path)
+	if os.IsNotExist(err) {
+		return s, nil
+	} else if err != nil {
+		return s, &Error{Op: "session: load", Err: err}
+	}
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return Session{}, &Error{Op: "session: load", Code: ErrInvalidJSON, Err: err}
+	}
+
+	return s, nil
+}
+
+// Save writes the Session to the file, readable only by the current user.
+// The file is replaced atomically so a partially written Session is
+// never loaded.
+func (f *FileSessionStore) Save(s Session) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return &Error{Op: "session: save", Err: err}
+	}
+
+	dir := filepath.Dir(f.Path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return &Error{Op: "session: save", Err: err}
+	}
+
+	tmp, err := ioutil.TempFile(dir, ".session-*")
+	if err != nil {
+		return &Error{Op: "session: save", Err: err}
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return &Error{Op: "session: save", Err: err}
+	}
+
+	if err := tmp.Close(); err != nil {
+		return &Error{Op: "session: save", Err: err}
+	}
+
+	if err := os.Rename(tmp.Name(), f.Path); err != nil {
+		return &Error{Op: "session: save", Err: err}
+	}
+
+	return nil
+}
+
+// Session returns the current session with the Hive API
+func (home *Home) Session() Session {
+	home.sessionMu.RLock()
+	defer home.sessionMu.RUnlock()
+
+	return home.session
+}
+
+func (home *Home) setSession(s Session) {
+	home.sessionMu.Lock()
+	defer home.sessionMu.Unlock()
+
+	home.session = s
+}
+
+func (home *Home) isClosed() bool {
+	home.sessionMu.RLock()
+	defer home.sessionMu.RUnlock()
+
+	return home.closed
+}
+
+// Close ends the session with the Hive API, see Logout.
+func (home *Home) Close() error {
+	return home.LogoutContext(context.Background())
+}
+
+// Logout ends the session with the Hive API. Afterwards the Home can no
+// longer be used, every request fails with an ErrClosed error.
+func (home *Home) Logout() error {
+	return home.LogoutContext(context.Background())
+}
+
+// LogoutContext ends the session with the Hive API using the provided
+// context. The Home is closed even if the session could not be ended.
+func (home *Home) LogoutContext(ctx context.Context) error {
+	// Prevent a concurrent re-login from replacing the session
+	home.loginMu.Lock()
+	defer home.loginMu.Unlock()
+
+	if home.isClosed() {
+		return nil
+	}
+
+	s := home.Session()
+
+	var err error
+	if s.ID != "" {
+		var resp *http.Response
+		resp, err = home.httpRequest(ctx, http.MethodDelete, "/omnia/auth/sessions/"+url.PathEscape(s.ID), nil)
+		if err == nil {
+			resp.Body.Close()
+		} else if ErrorCode(err) == ErrNotAuthorized {
+			err = nil // session has already expired
+		}
+	}
+
+	home.sessionMu.Lock()
+	home.session = Session{}
+	home.closed = true
+	home.sessionMu.Unlock()
+
+	if err != nil {
+		return &Error{Op: "logout", Err: err}
+	}
+
+	if home.store != nil {
+		if err := home.store.Save(Session{}); err != nil {
+			return &Error{Op: "logout", Err: err}
+		}
+	}
+
+	return nil
+}
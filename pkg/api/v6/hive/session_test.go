@@ -0,0 +1,210 @@
+package hive_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/geoffgarside/homekit-hive/pkg/api/v6/hive"
+	"github.com/geoffgarside/homekit-hive/pkg/api/v6/hive/hivetest"
+)
+
+func TestFileSessionStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hive-session")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir() error = %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	store := &hive.FileSessionStore{Path: filepath.Join(dir, "nested", "session.json")}
+
+	got, err := store.Load()
+	if err != nil || got.ID != "" {
+		t.Fatalf("FileSessionStore.Load() missing = %v, %v, want zero Session", got, err)
+	}
+
+	want := hive.Session{
+		ID:         "4wdz82NrUmdYCuuNz3wzofWGymjRWigL",
+		Username:   "username",
+		UserID:     "b3a1835b-d27a-4ce9-b095-830fe9f0e398",
+		APIVersion: "6",
+		IssuedAt:   time.Date(2020, 12, 1, 10, 30, 0, 0, time.UTC),
+	}
+
+	if err := store.Save(want); err != nil {
+		t.Fatalf("FileSessionStore.Save() error = %v, want nil", err)
+	}
+
+	info, err := os.Stat(store.Path)
+	if err != nil {
+		t.Fatalf("os.Stat() error = %v", err)
+	}
+
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("FileSessionStore.Save() mode = %v, want %v", perm, os.FileMode(0600))
+	}
+
+	got, err = store.Load()
+	if err != nil {
+		t.Fatalf("FileSessionStore.Load() error = %v, want nil", err)
+	}
+
+	if got != want {
+		t.Errorf("FileSessionStore.Load() = %+v, want %+v", got, want)
+	}
+
+	if err := ioutil.WriteFile(store.Path, []byte("{"), 0600); err != nil {
+		t.Fatalf("ioutil.WriteFile() error = %v", err)
+	}
+
+	if _, err := store.Load(); hive.ErrorCode(err) != hive.ErrInvalidJSON {
+		t.Errorf("FileSessionStore.Load() error = %v, want %v", err, hive.ErrInvalidJSON)
+	}
+}
+
+func TestHomeConnectSessionStore(t *testing.T) {
+	var logins int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.alertme.zoo-6.1+json;charset=UTF-8")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/omnia/auth/sessions":
+			logins++
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{
+				"sessions":[{
+					"id":"session-%[1]d",
+					"username":"username",
+					"userId":"b3a1835b-d27a-4ce9-b095-830fe9f0e398",
+					"extCustomerLevel":1,
+					"latestSupportedApiVersion":"6",
+					"sessionId":"session-%[1]d"
+				}]
+			}`, logins)
+		case r.Method == http.MethodGet && r.URL.Path == "/omnia/nodes":
+			if r.Header.Get("X-Omnia-Access-Token") != "session-1" {
+				http.Error(w,
+					`{"errors":[{"code":"NOT_AUTHORIZED","title":"Not authorized","links":[]}]}`,
+					http.StatusUnauthorized)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"nodes":[]}`)
+		default:
+			http.Error(w, "unknown path", http.StatusNotFound)
+		}
+	}))
+
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "hive-session")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir() error = %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	store := &hive.FileSessionStore{Path: filepath.Join(dir, "session.json")}
+
+	connect := func(username string, options ...hive.Option) *hive.Home {
+		home, err := hive.Connect(append([]hive.Option{
+			hive.WithCredentials(username, "password"),
+			hive.WithHTTPClient(srv.Client()),
+			hive.WithURL(srv.URL),
+		}, options...)...)
+		if err != nil {
+			t.Fatalf("hive.Connect() error = %v, want nil", err)
+		}
+
+		return home
+	}
+
+	home := connect("username", hive.WithSessionStore(store))
+	s := home.Session()
+
+	if logins != 1 || s.ID != "session-1" || s.Username != "username" || s.APIVersion != "6" || s.IssuedAt.IsZero() {
+		t.Fatalf("hive.Connect() logins = %v, session = %+v", logins, s)
+	}
+
+	if stored, _ := store.Load(); stored.ID != s.ID {
+		t.Errorf("FileSessionStore.Load() = %+v, want %+v", stored, s)
+	}
+
+	home = connect("username", hive.WithSessionStore(store))
+	if _, err := home.Nodes(); err != nil {
+		t.Errorf("Home.Nodes() with stored session error = %v, want nil", err)
+	}
+
+	if logins != 1 {
+		t.Errorf("hive.Connect() with stored session logins = %v, want 1", logins)
+	}
+
+	home = connect("username", hive.WithSession(s))
+	if _, err := home.Nodes(); err != nil {
+		t.Errorf("Home.Nodes() with session error = %v, want nil", err)
+	}
+
+	if logins != 1 {
+		t.Errorf("hive.Connect() with session logins = %v, want 1", logins)
+	}
+
+	connect("another", hive.WithSessionStore(store))
+	if logins != 2 {
+		t.Errorf("hive.Connect() with another user logins = %v, want 2", logins)
+	}
+
+	if stored, _ := store.Load(); stored.ID != "session-2" || stored.Username != "another" {
+		t.Errorf("FileSessionStore.Load() = %+v, want session-2 for another", stored)
+	}
+	if err := ioutil.WriteFile(store.Path, []byte("{"), 0600); err != nil {
+		t.Fatalf("ioutil.WriteFile() error = %v", err)
+	}
+
+	connect("username", hive.WithSessionStore(store))
+	if logins != 3 {
+		t.Errorf("hive.Connect() with corrupt store logins = %v, want 3", logins)
+	}
+
+	if stored, err := store.Load(); err != nil || stored.ID != "session-3" {
+		t.Errorf("FileSessionStore.Load() = %+v, %v, want session-3", stored, err)
+	}
+}
+
+type readOnlySessionStore struct{}
+
+func (readOnlySessionStore) Load() (hive.Session, error) { return hive.Session{}, nil }
+func (readOnlySessionStore) Save(hive.Session) error     { return errors.New("read-only file system") }
+
+func TestHomeConnectSessionStore_saveFails(t *testing.T) {
+	srv := hivetest.NewServer("username", "password")
+	defer srv.Close()
+
+	home, err := hive.Connect(
+		hive.WithCredentials("username", "password"),
+		hive.WithHTTPClient(srv.Client()),
+		hive.WithURL(srv.URL),
+		hive.WithSessionStore(readOnlySessionStore{}),
+	)
+	if err != nil {
+		t.Fatalf("hive.Connect() error = %v, want nil", err)
+	}
+
+	srv.ExpireSessions()
+
+	if _, err := home.Nodes(); err != nil {
+		t.Errorf("Home.Nodes() after relogin error = %v, want nil", err)
+	}
+
+	if got := srv.Logins(); got != 2 {
+		t.Errorf("Server.Logins() = %v, want 2", got)
+	}
+}
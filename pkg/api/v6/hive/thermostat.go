@@ -1,10 +1,9 @@
 package hive
 
 import (
-	"bytes"
-	"encoding/json"
-	"net/http"
-	"net/url"
+	"context"
+	"fmt"
+	"math"
 )
 
 // ActiveMode defines the active heating/cooling mode
@@ -17,12 +16,30 @@ const (
 	ActiveModeCooling
 )
 
+// ThermostatMode defines the operating mode of the thermostat
+type ThermostatMode int
+
+// ThermostatMode values
+const (
+	ThermostatModeOff ThermostatMode = iota
+	ThermostatModeSchedule
+	ThermostatModeManual
+)
+
+const nodeTypeThermostat = "http://alertme.com/schema/json/node.class.thermostat.json#"
+
 const (
 	// ThermostatDefaultMinimum is the default minimum heating temperature
 	ThermostatDefaultMinimum = 5.0
 
 	// ThermostatDefaultMaximum is the default maximum heating temperature
 	ThermostatDefaultMaximum = 35.0
+
+	// ThermostatDefaultFrostProtect is the default frost protection temperature
+	ThermostatDefaultFrostProtect = 7.0
+
+	// ThermostatDefaultStep is the default resolution of target temperatures
+	ThermostatDefaultStep = 0.5
 )
 
 // Thermostat is a Hive managed Thermostat
@@ -37,7 +54,7 @@ type Thermostat struct {
 
 // ActiveMode returns the current active heating/cooling mode
 func (t *Thermostat) ActiveMode() (ActiveMode, error) {
-	v, ok := t.node.attr("activeHeatCoolMode").ReportedValueString()
+	v, ok := t.current().attr("activeHeatCoolMode").ReportedValueString()
 	if !ok {
 		return ActiveModeOff, &Error{
 			Op:      "thermostat: temperature",
@@ -56,9 +73,67 @@ func (t *Thermostat) ActiveMode() (ActiveMode, error) {
 	}
 }
 
+// Heating returns true if the thermostat is currently calling for heat
+// from the boiler, rather than just having heating enabled.
+func (t *Thermostat) Heating() (bool, error) {
+	v, ok := t.current().attr("stateHeatingRelay").ReportedValueString()
+	if !ok {
+		return false, &Error{
+			Op:      "thermostat: heating",
+			Code:    ErrInvalidDataType,
+			Message: "invalid data type",
+		}
+	}
+
+	return v == "ON", nil
+}
+
+// Mode returns the current operating mode of the thermostat. The
+// thermostat is considered off when heating is disabled or when it is
+// held at its frost protection temperature. While boosting the
+// thermostat reports ThermostatModeManual.
+func (t *Thermostat) Mode() (ThermostatMode, error) {
+	mode, ok := t.current().attr("activeHeatCoolMode").ReportedValueString()
+	if !ok {
+		return ThermostatModeOff, &Error{
+			Op:      "thermostat: mode",
+			Code:    ErrInvalidDataType,
+			Message: "invalid data type",
+		}
+	}
+
+	switch mode {
+	case "HEAT":
+		break
+	case "BOOST":
+		return ThermostatModeManual, nil
+	default:
+		return ThermostatModeOff, nil
+	}
+
+	lock, ok := t.current().attr("activeScheduleLock").ReportedValueBool()
+	if !ok {
+		return ThermostatModeOff, &Error{
+			Op:      "thermostat: mode",
+			Code:    ErrInvalidDataType,
+			Message: "invalid data type",
+		}
+	}
+
+	if !lock {
+		return ThermostatModeSchedule, nil
+	}
+
+	if target, ok := t.current().attr("targetHeatTemperature").ReportedValueFloat(); ok && target <= t.FrostProtect() {
+		return ThermostatModeOff, nil
+	}
+
+	return ThermostatModeManual, nil
+}
+
 // Temperature returns the current measured temperature
 func (t *Thermostat) Temperature() (float64, error) {
-	v, ok := t.node.attr("temperature").ReportedValueFloat()
+	v, ok := t.current().attr("temperature").ReportedValueFloat()
 	if !ok {
 		return t.Minimum(), &Error{
 			Op:      "thermostat: temperature",
@@ -80,7 +155,7 @@ func (t *Thermostat) Temperature() (float64, error) {
 
 // Target returns the target temperature setting
 func (t *Thermostat) Target() (float64, error) {
-	v, ok := t.node.attr("targetHeatTemperature").ReportedValueFloat()
+	v, ok := t.current().attr("targetHeatTemperature").ReportedValueFloat()
 	if !ok {
 		return t.Minimum(), &Error{
 			Op:      "thermostat: target temperature",
@@ -102,7 +177,7 @@ func (t *Thermostat) Target() (float64, error) {
 
 // Minimum returns the minimum valid temperature
 func (t *Thermostat) Minimum() float64 {
-	v, ok := t.node.attr("minHeatTemperature").ReportedValueFloat()
+	v, ok := t.current().attr("minHeatTemperature").ReportedValueFloat()
 	if !ok {
 		return ThermostatDefaultMinimum
 	}
@@ -112,7 +187,7 @@ func (t *Thermostat) Minimum() float64 {
 
 // Maximum returns the maximum valid temperature
 func (t *Thermostat) Maximum() float64 {
-	v, ok := t.node.attr("maxHeatTemperature").ReportedValueFloat()
+	v, ok := t.current().attr("maxHeatTemperature").ReportedValueFloat()
 	if !ok {
 		return ThermostatDefaultMaximum
 	}
@@ -120,9 +195,64 @@ func (t *Thermostat) Maximum() float64 {
 	return v
 }
 
+// Step returns the resolution target temperatures are set at, falling
+// back to ThermostatDefaultStep when the thermostat does not report one.
+func (t *Thermostat) Step() float64 {
+	v, ok := t.current().attr("stepHeatTemperature").ReportedValueFloat()
+	if !ok || v <= 0 {
+		return ThermostatDefaultStep
+	}
+
+	return v
+}
+
+// setpoint rounds temp to the nearest Step, returning an ErrOutOfRange
+// error if the result is outside the Minimum and Maximum temperatures.
+func (t *Thermostat) setpoint(op string, temp float64) (float64, error) {
+	min, max := t.Minimum(), t.Maximum()
+
+	if math.IsNaN(temp) || math.IsInf(temp, 0) {
+		return 0, &Error{
+			Op:      op,
+			Code:    ErrOutOfRange,
+			Message: fmt.Sprintf("temperature %v is not a number", temp),
+		}
+	}
+
+	if step := t.Step(); step > 0 {
+		temp = math.Round(temp/step) * step
+	}
+
+	if temp < min || temp > max {
+		return 0, &Error{
+			Op:      op,
+			Code:    ErrOutOfRange,
+			Message: fmt.Sprintf("temperature %v outside range %v to %v", temp, min, max),
+		}
+	}
+
+	return temp, nil
+}
+
+// FrostProtect returns the frost protection temperature
+func (t *Thermostat) FrostProtect() float64 {
+	v, ok := t.current().attr("frostProtectTemperature").ReportedValueFloat()
+	if !ok {
+		return ThermostatDefaultFrostProtect
+	}
+
+	return v
+}
+
 // Update fetches the latest information about the Thermostat from the API
 func (t *Thermostat) Update() error {
-	n, err := t.home.node(t.Href)
+	return t.UpdateContext(context.Background())
+}
+
+// UpdateContext fetches the latest information about the Thermostat from
+// the API using the provided context.
+func (t *Thermostat) UpdateContext(ctx context.Context) error {
+	n, err := t.home.node(ctx, t.Href)
 	if err != nil {
 		return &Error{Op: "thermostat: update", Err: err}
 	}
@@ -131,19 +261,28 @@ func (t *Thermostat) Update() error {
 		return &Error{Op: "thermostat: update", Code: ErrInvalidUpdate, Message: "update failed, ID mismatch"}
 	}
 
-	t.node = n
+	t.refresh(n)
 	return nil
 }
 
 // Thermostats returns the list of thermostats in the Home
 func (home *Home) Thermostats() ([]*Thermostat, error) {
-	const nodeTypeThermostat = "http://alertme.com/schema/json/node.class.thermostat.json#"
+	return home.ThermostatsContext(context.Background())
+}
 
-	nodes, err := home.nodes()
+// ThermostatsContext returns the list of thermostats in the Home using
+// the provided context.
+func (home *Home) ThermostatsContext(ctx context.Context) ([]*Thermostat, error) {
+	nodes, err := home.nodes(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	return home.newThermostats(nodes), nil
+}
+
+// newThermostats returns a Thermostat for each thermostat in nodes
+func (home *Home) newThermostats(nodes []*node) []*Thermostat {
 	var thermostats []*Thermostat
 
 	for _, n := range nodes {
@@ -157,81 +296,79 @@ func (home *Home) Thermostats() ([]*Thermostat, error) {
 		}
 
 		n := n
-		thermostats = append(thermostats, &Thermostat{
+		thermostats = append(thermostats, home.track(&Thermostat{
 			ID:   n.ID,
 			Name: n.Name,
 			Href: n.Href,
 			home: home,
 			node: n,
-		})
+		}).(*Thermostat))
 	}
 
-	return thermostats, nil
+	return thermostats
 }
 
-// SetTarget sets the target temperature of the Thermostat
+// SetTarget sets the target temperature of the Thermostat, rounded to
+// the nearest Step. Temperatures outside the Minimum and Maximum of the
+// Thermostat are rejected with an ErrOutOfRange error.
 func (t *Thermostat) SetTarget(temp float64) error {
-	n, err := t.home.setThermostat(t, temp)
+	return t.SetTargetContext(context.Background(), temp)
+}
+
+// SetTargetContext sets the target temperature of the Thermostat using
+// the provided context.
+func (t *Thermostat) SetTargetContext(ctx context.Context, temp float64) error {
+	temp, err := t.setpoint("thermostat: set temperature", temp)
 	if err != nil {
 		return err
 	}
 
-	t.node = n
-	return nil
+	return t.home.setAttributes(ctx, "thermostat: set temperature", t, nodeAttributes{
+		"targetHeatTemperature": {TargetValue: temp},
+	})
 }
 
-// setThermostat sets the target temperature of the Thermostat
-func (home *Home) setThermostat(t *Thermostat, targetTemp float64) (*node, error) {
-	body := &nodesResponse{
-		Nodes: []*node{{
-			Attributes: nodeAttributes{
-				"targetHeatTemperature": {
-					TargetValue: targetTemp,
-				},
-			},
-		}},
-	}
-
-	uri, err := url.Parse(t.Href)
-	if err != nil {
-		return nil, &Error{Op: "", Err: err}
-	}
-
-	buf := &bytes.Buffer{}
-	if err := json.NewEncoder(buf).Encode(body); err != nil {
-		return nil, &Error{
-			Op:  "thermostat: set temperature: encode json",
-			Err: err,
-		}
-	}
+// SetMode sets the operating mode of the Thermostat
+func (t *Thermostat) SetMode(mode ThermostatMode) error {
+	return t.SetModeContext(context.Background(), mode)
+}
 
-	rs := bytes.NewReader(buf.Bytes()) // convert JSON bytes into bytes.Reader to support io.ReadSeeker
-	resp, err := home.httpRequestWithSession(http.MethodPut, uri.RequestURI(), rs)
+// SetModeContext sets the operating mode of the Thermostat using the
+// provided context. Turning the thermostat off also holds it at its
+// frost protection temperature.
+func (t *Thermostat) SetModeContext(ctx context.Context, mode ThermostatMode) error {
+	attrs, err := t.modeAttributes(mode)
 	if err != nil {
-		return nil, &Error{
-			Op:  "thermostat: set temperature: request",
-			Err: err,
-		}
+		return err
 	}
 
-	defer resp.Body.Close()
-
-	var response nodesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, &Error{
-			Op:   "thermostat: set temperature: read body",
-			Code: ErrInvalidJSON,
-			Err:  err,
-		}
-	}
+	return t.home.setAttributes(ctx, "thermostat: set mode", t, attrs)
+}
 
-	if len(response.Nodes) != 1 {
+// modeAttributes returns the node attributes which select the mode
+func (t *Thermostat) modeAttributes(mode ThermostatMode) (nodeAttributes, error) {
+	switch mode {
+	case ThermostatModeOff:
+		return nodeAttributes{
+			"activeHeatCoolMode":    {TargetValue: "OFF"},
+			"activeScheduleLock":    {TargetValue: true},
+			"targetHeatTemperature": {TargetValue: t.FrostProtect()},
+		}, nil
+	case ThermostatModeSchedule:
+		return nodeAttributes{
+			"activeHeatCoolMode": {TargetValue: "HEAT"},
+			"activeScheduleLock": {TargetValue: false},
+		}, nil
+	case ThermostatModeManual:
+		return nodeAttributes{
+			"activeHeatCoolMode": {TargetValue: "HEAT"},
+			"activeScheduleLock": {TargetValue: true},
+		}, nil
+	default:
 		return nil, &Error{
-			Op:      "thermostat: set temperature",
-			Code:    ErrNodeNotFound,
-			Message: "incorrect number of nodes returned",
+			Op:      "thermostat: set mode",
+			Code:    ErrInvalidDataType,
+			Message: "unknown thermostat mode",
 		}
 	}
-
-	return response.Nodes[0], nil
 }
@@ -3,6 +3,7 @@ package hive
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -549,6 +550,34 @@ func TestThermostat_Update(t *testing.T) {
 	}
 }
 
+func TestThermostat_Step(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  float64
+	}{
+		{"Valid", 1.0, 1.0},
+		{"Invalid", "str", ThermostatDefaultStep},
+		{"Zero", 0.0, ThermostatDefaultStep},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &Thermostat{
+				node: &node{
+					Attributes: nodeAttributes{
+						"stepHeatTemperature": &nodeAttribute{
+							ReportedValue: tt.value,
+						},
+					},
+				},
+			}
+			if got := ts.Step(); got != tt.want {
+				t.Errorf("Thermostat.Step() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestThermostat_SetTarget(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPut {
@@ -664,18 +693,35 @@ func TestThermostat_SetTarget(t *testing.T) {
 		httpClient: srv.Client(),
 	}
 
+	thermostat := func() *Thermostat {
+		return &Thermostat{
+			ID:   "fe49e95e-c8cc-47cc-b38f-ec0c06361e13",
+			Name: "Receiver 1",
+			Href: "https://api-prod.bgchprod.info/omnia/nodes/fe49e95e-c8cc-47cc-b38f-ec0c06361e13",
+			home: home,
+			node: &node{Attributes: nodeAttributes{}},
+		}
+	}
+
+	wholeDegrees := thermostat()
+	wholeDegrees.node.Attributes["stepHeatTemperature"] = &nodeAttribute{ReportedValue: 1.0}
+
 	tests := []struct {
 		name       string
 		target     float64
 		thermostat *Thermostat
 		wantErr    bool
+		want       float64
 	}{
-		{"Valid", 17.5, &Thermostat{
-			ID:   "fe49e95e-c8cc-47cc-b38f-ec0c06361e13",
-			Name: "Receiver 1",
-			Href: "https://api-prod.bgchprod.info/omnia/nodes/fe49e95e-c8cc-47cc-b38f-ec0c06361e13",
-			home: home,
-		}, false},
+		{"Valid", 17.5, thermostat(), false, 17.5},
+		{"Rounded Down", 17.7, thermostat(), false, 17.5},
+		{"Rounded Up", 17.8, thermostat(), false, 18},
+		{"Minimum", 5, thermostat(), false, 5},
+		{"Maximum", 35, thermostat(), false, 35},
+		{"Below Minimum", 4.5, thermostat(), true, 0},
+		{"Above Maximum", 35.5, thermostat(), true, 0},
+		{"Not A Number", math.NaN(), thermostat(), true, 0},
+		{"Reported Step", 17.7, wholeDegrees, false, 18},
 	}
 
 	for _, tt := range tests {
@@ -685,6 +731,197 @@ func TestThermostat_SetTarget(t *testing.T) {
 				t.Errorf("Thermostat.SetTarget() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+
+			if err != nil {
+				if !HasCode(err, ErrOutOfRange) {
+					t.Errorf("Thermostat.SetTarget() error = %v, want %v", err, ErrOutOfRange)
+				}
+
+				return
+			}
+
+			if got, _ := tt.thermostat.current().attr("targetHeatTemperature").TargetValueFloat(); got != tt.want {
+				t.Errorf("Thermostat.SetTarget() sent %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThermostat_Heating(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    bool
+		wantErr bool
+	}{
+		{"On", "ON", true, false},
+		{"Off", "OFF", false, false},
+		{"Invalid", 100, false, true},
+		{"Missing", nil, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &Thermostat{node: &node{Attributes: nodeAttributes{}}}
+
+			if tt.value != nil {
+				ts.node.Attributes["stateHeatingRelay"] = &nodeAttribute{ReportedValue: tt.value}
+			}
+
+			got, err := ts.Heating()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Thermostat.Heating() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Thermostat.Heating() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThermostat_Mode(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    interface{}
+		lock    interface{}
+		target  interface{}
+		want    ThermostatMode
+		wantErr bool
+	}{
+		{"Off", "OFF", true, 7.0, ThermostatModeOff, false},
+		{"FrostProtect", "HEAT", true, 7.0, ThermostatModeOff, false},
+		{"Schedule", "HEAT", false, 18.0, ThermostatModeSchedule, false},
+		{"Manual", "HEAT", true, 18.0, ThermostatModeManual, false},
+		{"InvalidMode", 100, true, 18.0, ThermostatModeOff, true},
+		{"InvalidLock", "HEAT", "yes", 18.0, ThermostatModeOff, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &Thermostat{
+				node: &node{
+					Attributes: nodeAttributes{
+						"activeHeatCoolMode": &nodeAttribute{
+							ReportedValue: tt.mode,
+						},
+						"activeScheduleLock": &nodeAttribute{
+							ReportedValue: tt.lock,
+						},
+						"targetHeatTemperature": &nodeAttribute{
+							ReportedValue: tt.target,
+						},
+					},
+				},
+			}
+			got, err := ts.Mode()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Thermostat.Mode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Thermostat.Mode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThermostat_SetMode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			http.Error(w, "must be put", http.StatusBadRequest)
+			return
+		}
+
+		if r.URL.Path != "/omnia/nodes/fe49e95e-c8cc-47cc-b38f-ec0c06361e13" {
+			http.Error(w, "unknown path", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.alertme.zoo-6.1+json;charset=UTF-8")
+
+		var req nodesResponse
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Nodes) != 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors": [{"code": "INVALID_PARAMETER","title": "Node configuration error", "links": []}]}`))
+			return
+		}
+
+		mode, _ := req.Nodes[0].attr("activeHeatCoolMode").TargetValueString()
+		lock, _ := req.Nodes[0].attr("activeScheduleLock").TargetValueBool()
+		target, ok := req.Nodes[0].attr("targetHeatTemperature").TargetValueFloat()
+		if !ok {
+			target = 18.0
+		}
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{
+			"nodes": [{
+				"id": "fe49e95e-c8cc-47cc-b38f-ec0c06361e13",
+				"href": "https://api-prod.bgchprod.info/omnia/nodes/fe49e95e-c8cc-47cc-b38f-ec0c06361e13",
+				"name": "Receiver 1",
+				"attributes": {
+					"activeHeatCoolMode": {
+						"reportedValue": %q,
+						"displayValue": %q
+					},
+					"activeScheduleLock": {
+						"reportedValue": %v,
+						"displayValue": %v
+					},
+					"targetHeatTemperature": {
+						"reportedValue": %v,
+						"displayValue": %v
+					},
+					"frostProtectTemperature": {
+						"reportedValue": 7.0,
+						"displayValue": 7.0
+					}
+				}
+			}]
+		}`, mode, mode, lock, lock, target, target)
+	}))
+
+	defer srv.Close()
+
+	baseURL, _ := url.Parse(srv.URL)
+	home := &Home{
+		baseURL:    baseURL,
+		httpClient: srv.Client(),
+	}
+
+	tests := []struct {
+		name    string
+		mode    ThermostatMode
+		wantErr bool
+	}{
+		{"Off", ThermostatModeOff, false},
+		{"Schedule", ThermostatModeSchedule, false},
+		{"Manual", ThermostatModeManual, false},
+		{"Invalid", ThermostatMode(100), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &Thermostat{
+				ID:   "fe49e95e-c8cc-47cc-b38f-ec0c06361e13",
+				Name: "Receiver 1",
+				Href: "https://api-prod.bgchprod.info/omnia/nodes/fe49e95e-c8cc-47cc-b38f-ec0c06361e13",
+				home: home,
+				node: &node{},
+			}
+
+			err := ts.SetMode(tt.mode)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Thermostat.SetMode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got, _ := ts.Mode(); got != tt.mode {
+				t.Errorf("Thermostat.Mode() = %v, want %v", got, tt.mode)
+			}
 		})
 	}
 }
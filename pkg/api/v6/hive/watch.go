@@ -0,0 +1,244 @@
+package hive
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// EventType identifies the kind of change an Event describes
+type EventType int
+
+// EventType values
+const (
+	EventError EventType = iota
+	EventTemperatureChanged
+	EventTargetChanged
+	EventModeChanged
+	EventBatteryChanged
+	EventDeviceAdded
+	EventDeviceRemoved
+	EventDeviceOffline
+	EventDeviceOnline
+)
+
+var eventTypeNames = [...]string{
+	EventError:              "error",
+	EventTemperatureChanged: "temperature changed",
+	EventTargetChanged:      "target changed",
+	EventModeChanged:        "mode changed",
+	EventBatteryChanged:     "battery changed",
+	EventDeviceAdded:        "device added",
+	EventDeviceRemoved:      "device removed",
+	EventDeviceOffline:      "device offline",
+	EventDeviceOnline:       "device online",
+}
+
+func (t EventType) String() string {
+	if t < 0 || int(t) >= len(eventTypeNames) {
+		return fmt.Sprintf("EventType(%d)", int(t))
+	}
+
+	return eventTypeNames[t]
+}
+
+// Event is a change observed by Home.Watch. Old and New hold the values
+// before and after the change: float64 temperatures, an int battery
+// percentage, and a ThermostatMode or HotWaterMode. Err is only set for
+// EventError.
+type Event struct {
+	Type   EventType
+	NodeID string
+	Name   string
+	Time   time.Time
+
+	Old interface{}
+	New interface{}
+	Err error
+}
+
+func (e Event) String() string {
+	switch e.Type {
+	case EventError:
+		return fmt.Sprintf("%v: %v", e.Type, e.Err)
+	case EventTemperatureChanged, EventTargetChanged, EventModeChanged, EventBatteryChanged:
+		return fmt.Sprintf("%v %v: %v -> %v", e.Name, e.Type, e.Old, e.New)
+	default:
+		return fmt.Sprintf("%v %v", e.Name, e.Type)
+	}
+}
+
+// Watch polls every node in the Home each interval and sends an Event
+// for each change between successive polls. The first poll only records
+// the state of the Home. Devices handed out by the Home are refreshed by
+// each poll. Failed polls are sent as EventError events and polling
+// continues. The channel is closed once ctx is done. An interval which is
+// not positive is sent as a single EventError event before the channel is
+// closed.
+func (home *Home) Watch(ctx context.Context, interval time.Duration) <-chan Event {
+	events := make(chan Event, 16)
+
+	go func() {
+		defer close(events)
+
+		send := func(e Event) bool {
+			select {
+			case events <- e:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		if interval <= 0 {
+			send(Event{Type: EventError, Time: home.clock().Now(), Err: &Error{
+				Op:      "home: watch",
+				Code:    ErrInvalidDuration,
+				Message: "watch interval must be positive",
+			}})
+			return
+		}
+
+		var prev map[string]*node
+
+		poll := func() bool {
+			nodes, err := home.nodes(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return false
+				}
+
+				return send(Event{Type: EventError, Time: home.clock().Now(), Err: &Error{Op: "home: watch", Err: err}})
+			}
+
+			home.refreshDevices(nodes)
+
+			next := make(map[string]*node, len(nodes))
+			for _, n := range nodes {
+				next[n.ID] = n
+			}
+
+			if prev != nil {
+				for _, e := range diffNodes(prev, next, home.clock().Now()) {
+					if !send(e) {
+						return false
+					}
+				}
+			}
+
+			prev = next
+			return true
+		}
+
+		if !poll() {
+			return
+		}
+
+		t := home.clock().NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-t.C():
+				if !poll() {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// diffNodes returns the events describing the changes from prev to next,
+// ordered by node ID.
+func diffNodes(prev, next map[string]*node, now time.Time) []Event {
+	ids := make([]string, 0, len(prev)+len(next))
+	for id := range next {
+		ids = append(ids, id)
+	}
+
+	for id := range prev {
+		if _, ok := next[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+
+	var events []Event
+
+	for _, id := range ids {
+		p, n := prev[id], next[id]
+
+		switch {
+		case p == nil:
+			events = append(events, Event{Type: EventDeviceAdded, NodeID: id, Name: n.Name, Time: now})
+			continue
+		case n == nil:
+			events = append(events, Event{Type: EventDeviceRemoved, NodeID: id, Name: p.Name, Time: now})
+			continue
+		}
+
+		event := func(t EventType, before, after interface{}) {
+			events = append(events, Event{Type: t, NodeID: id, Name: n.Name, Time: now, Old: before, New: after})
+		}
+
+		if was, is := p.online(0, now), n.online(0, now); was && !is {
+			event(EventDeviceOffline, nil, nil)
+		} else if !was && is {
+			event(EventDeviceOnline, nil, nil)
+		}
+
+		for _, c := range []struct {
+			attr string
+			typ  EventType
+		}{
+			{"temperature", EventTemperatureChanged},
+			{"targetHeatTemperature", EventTargetChanged},
+		} {
+			before, ok1 := p.attr(c.attr).ReportedValueFloat()
+			after, ok2 := n.attr(c.attr).ReportedValueFloat()
+			if ok1 && ok2 && before != after {
+				event(c.typ, before, after)
+			}
+		}
+
+		if before, after := nodeMode(p), nodeMode(n); before != nil && after != nil && before != after {
+			event(EventModeChanged, before, after)
+		}
+
+		before, ok1 := p.attr("batteryLevel").ReportedValueFloat()
+		after, ok2 := n.attr("batteryLevel").ReportedValueFloat()
+		if ok1 && ok2 && int(before) != int(after) {
+			event(EventBatteryChanged, int(before), int(after))
+		}
+	}
+
+	return events
+}
+
+// nodeMode returns the ThermostatMode or HotWaterMode of n, or nil if n
+// is neither.
+func nodeMode(n *node) interface{} {
+	if nt, err := n.NodeType(); err != nil || nt != nodeTypeThermostat {
+		return nil
+	}
+
+	if _, ok := n.Attributes["stateHotWaterRelay"]; ok {
+		if mode, err := (&HotWater{node: n}).Mode(); err == nil {
+			return mode
+		}
+	}
+
+	if _, ok := n.Attributes["temperature"]; ok {
+		if mode, err := (&Thermostat{node: n}).Mode(); err == nil {
+			return mode
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package hive
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"github.com/geoffgarside/homekit-hive/pkg/api/v6/hive/hivetest"
+)
+
+type fakeClock struct {
+	now     time.Time
+	tickers chan *fakeTicker
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) NewTicker(d time.Duration) ticker {
+	t := &fakeTicker{c: make(chan time.Time)}
+	c.tickers <- t
+	return t
+}
+
+type fakeTicker struct {
+	c chan time.Time
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.c }
+func (t *fakeTicker) Stop()               {}
+
+func TestHome_Watch(t *testing.T) {
+	srv := hivetest.NewServer("username", "password")
+	defer srv.Close()
+
+	srv.AddNode(hivetest.Thermostat("t1", "Heating", 19.5, 20))
+	srv.AddNode(hivetest.Controller("c1", "Thermostat", 80))
+
+	home, err := Connect(
+		WithCredentials("username", "password"),
+		WithHTTPClient(srv.Client()),
+		WithURL(srv.URL),
+	)
+	if err != nil {
+		t.Fatalf("Connect() error = %v, want nil", err)
+	}
+
+	thermostats, err := home.Thermostats()
+	if err != nil || len(thermostats) != 1 {
+		t.Fatalf("Home.Thermostats() = %v, %v, want 1 thermostat", thermostats, err)
+	}
+
+	clk := &fakeClock{now: time.Unix(1600000000, 0), tickers: make(chan *fakeTicker)}
+	home.clk = clk
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := home.Watch(ctx, time.Minute)
+
+	// the ticker is created once the initial state has been recorded
+	tk := <-clk.tickers
+
+	receive := func(n int) []Event {
+		var got []Event
+		for len(got) < n {
+			select {
+			case e := <-events:
+				got = append(got, e)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("Home.Watch() events = %v, want %v events", got, n)
+			}
+		}
+
+		return got
+	}
+
+	srv.Report("t1", "temperature", 20.5)
+	srv.Report("t1", "targetHeatTemperature", 21.0)
+	srv.Report("t1", "activeScheduleLock", false)
+	srv.Report("c1", "batteryLevel", 70.0)
+	srv.AddNode(hivetest.Controller("c2", "Landing", 100))
+
+	tk.c <- clk.now
+
+	want := []Event{
+		{Type: EventBatteryChanged, NodeID: "c1", Name: "Thermostat", Time: clk.now, Old: 80, New: 70},
+		{Type: EventDeviceAdded, NodeID: "c2", Name: "Landing", Time: clk.now},
+		{Type: EventTemperatureChanged, NodeID: "t1", Name: "Heating", Time: clk.now, Old: 19.5, New: 20.5},
+		{Type: EventTargetChanged, NodeID: "t1", Name: "Heating", Time: clk.now, Old: 20.0, New: 21.0},
+		{Type: EventModeChanged, NodeID: "t1", Name: "Heating", Time: clk.now,
+			Old: ThermostatModeManual, New: ThermostatModeSchedule},
+	}
+	if diff := deep.Equal(receive(len(want)), want); diff != nil {
+		t.Errorf("Home.Watch() events diff = %v", diff)
+	}
+
+	if got, _ := thermostats[0].Temperature(); got != 20.5 {
+		t.Errorf("Thermostat.Temperature() = %v, want 20.5 after watch poll", got)
+	}
+
+	srv.Report("c1", "presence", "ABSENT")
+	srv.RemoveNode("c2")
+
+	tk.c <- clk.now
+
+	want = []Event{
+		{Type: EventDeviceOffline, NodeID: "c1", Name: "Thermostat", Time: clk.now},
+		{Type: EventDeviceRemoved, NodeID: "c2", Name: "Landing", Time: clk.now},
+	}
+	if diff := deep.Equal(receive(len(want)), want); diff != nil {
+		t.Errorf("Home.Watch() events diff = %v", diff)
+	}
+
+	srv.FailNext(1, hivetest.Failure{Status: http.StatusServiceUnavailable, Code: "UNAVAILABLE"})
+
+	tk.c <- clk.now
+
+	if got := receive(1); got[0].Type != EventError || StatusCode(got[0].Err) != http.StatusServiceUnavailable {
+		t.Errorf("Home.Watch() event = %v, want %v", got[0], EventError)
+	}
+
+	cancel()
+
+	for range events {
+	}
+}
+
+func TestHome_Watch_invalidInterval(t *testing.T) {
+	srv := hivetest.NewServer("username", "password")
+	defer srv.Close()
+
+	home, err := Connect(
+		WithCredentials("username", "password"),
+		WithHTTPClient(srv.Client()),
+		WithURL(srv.URL),
+	)
+	if err != nil {
+		t.Fatalf("Connect() error = %v, want nil", err)
+	}
+
+	for _, interval := range []time.Duration{0, -time.Minute} {
+		events := home.Watch(context.Background(), interval)
+
+		e, ok := <-events
+		if !ok || e.Type != EventError || ErrorCode(e.Err) != ErrInvalidDuration {
+			t.Errorf("Home.Watch(%v) event = %v, want %v error", interval, e, ErrInvalidDuration)
+		}
+
+		if _, ok := <-events; ok {
+			t.Errorf("Home.Watch(%v) channel open, want closed", interval)
+		}
+	}
+}
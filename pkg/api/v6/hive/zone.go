@@ -0,0 +1,165 @@
+package hive
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Zone is a heating zone of the Home, a thermostat along with the
+// controller used to adjust it and the devices which make it up.
+type Zone struct {
+	// Hub is the Hive hub the Receiver is connected through
+	Hub *Node
+
+	// Receiver is the boiler receiver switching the zone
+	Receiver *Node
+
+	Thermostat *Thermostat
+	Controller *Controller
+	HotWater   *HotWater
+}
+
+type nodeRelationships struct {
+	BoundNodes []struct {
+		ID   string `json:"id"`
+		Href string `json:"href"`
+	} `json:"boundNodes"`
+}
+
+// boundNodeIDs returns the IDs of the nodes bound to n
+func (n *node) boundNodeIDs() []string {
+	if len(n.Relationships) == 0 {
+		return nil
+	}
+
+	var rels nodeRelationships
+	if err := json.Unmarshal(n.Relationships, &rels); err != nil {
+		return nil
+	}
+
+	ids := make([]string, 0, len(rels.BoundNodes))
+	for _, b := range rels.BoundNodes {
+		ids = append(ids, b.ID)
+	}
+
+	return ids
+}
+
+// bound returns true if either node lists the other as a bound node
+func bound(a, b *node) bool {
+	for _, id := range a.boundNodeIDs() {
+		if id == b.ID {
+			return true
+		}
+	}
+
+	for _, id := range b.boundNodeIDs() {
+		if id == a.ID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Zones returns the heating zones of the Home
+func (home *Home) Zones() ([]*Zone, error) {
+	return home.ZonesContext(context.Background())
+}
+
+// ZonesContext returns the heating zones of the Home using the provided
+// context. Controllers are paired with thermostats from the node
+// relationships, when a Home has a single thermostat and controller
+// without relationships they are assumed to be paired.
+func (home *Home) ZonesContext(ctx context.Context) ([]*Zone, error) {
+	nodes, err := home.nodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return home.newZones(nodes), nil
+}
+
+func (home *Home) newZones(nodes []*node) []*Zone {
+	byID := make(map[string]*node, len(nodes))
+	for _, n := range nodes {
+		byID[n.ID] = n
+	}
+
+	parent := func(n *node) *Node {
+		if n == nil {
+			return nil
+		}
+
+		if p, ok := byID[n.ParentNodeID]; ok {
+			return home.newNode(p)
+		}
+
+		return nil
+	}
+
+	thermostats := home.newThermostats(nodes)
+	controllers := home.newControllers(nodes)
+	hotWater := home.newHotWater(nodes)
+
+	zones := make([]*Zone, 0, len(thermostats))
+
+	for _, t := range thermostats {
+		z := &Zone{
+			Thermostat: t,
+			Receiver:   parent(t.current()),
+		}
+
+		if z.Receiver != nil {
+			z.Hub = parent(z.Receiver.current())
+		}
+
+		for _, c := range controllers {
+			if bound(t.current(), c.current()) {
+				z.Controller = c
+				break
+			}
+		}
+
+		for _, hw := range hotWater {
+			if hw.current().ParentNodeID != "" && hw.current().ParentNodeID == t.current().ParentNodeID {
+				z.HotWater = hw
+				break
+			}
+		}
+
+		zones = append(zones, z)
+	}
+
+	if len(zones) == 1 && len(controllers) == 1 && zones[0].Controller == nil {
+		zones[0].Controller = controllers[0]
+	}
+
+	return zones
+}
+
+// Controller returns the Controller paired with the Thermostat
+func (t *Thermostat) Controller() (*Controller, error) {
+	return t.ControllerContext(context.Background())
+}
+
+// ControllerContext returns the Controller paired with the Thermostat
+// using the provided context.
+func (t *Thermostat) ControllerContext(ctx context.Context) (*Controller, error) {
+	zones, err := t.home.ZonesContext(ctx)
+	if err != nil {
+		return nil, &Error{Op: "thermostat: controller", Err: err}
+	}
+
+	for _, z := range zones {
+		if z.Thermostat.ID == t.ID && z.Controller != nil {
+			return z.Controller, nil
+		}
+	}
+
+	return nil, &Error{
+		Op:      "thermostat: controller",
+		Code:    ErrNodeNotFound,
+		Message: "no controller paired with thermostat",
+	}
+}
@@ -0,0 +1,213 @@
+package hive
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+const zonesJSON = `{
+	"nodes": [{
+		"id": "hub",
+		"href": "https://api-prod.bgchprod.info/omnia/nodes/hub",
+		"name": "Hub",
+		"attributes": {
+			"nodeType": {"reportedValue": "http://alertme.com/schema/json/node.class.hub.json#"}
+		}
+	}, {
+		"id": "ui-upstairs",
+		"href": "https://api-prod.bgchprod.info/omnia/nodes/ui-upstairs",
+		"name": "Upstairs Thermostat",
+		"parentNodeId": "hub",
+		"attributes": {
+			"nodeType": {"reportedValue": "http://alertme.com/schema/json/node.class.thermostatui.json#"}
+		},
+		"relationships": {
+			"boundNodes": [{"id": "heating-upstairs", "href": "https://api-prod.bgchprod.info/omnia/nodes/heating-upstairs"}]
+		}
+	}, {
+		"id": "ui-downstairs",
+		"href": "https://api-prod.bgchprod.info/omnia/nodes/ui-downstairs",
+		"name": "Downstairs Thermostat",
+		"parentNodeId": "hub",
+		"attributes": {
+			"nodeType": {"reportedValue": "http://alertme.com/schema/json/node.class.thermostatui.json#"}
+		},
+		"relationships": {
+			"boundNodes": [{"id": "heating-downstairs", "href": "https://api-prod.bgchprod.info/omnia/nodes/heating-downstairs"}]
+		}
+	}, {
+		"id": "receiver-downstairs",
+		"href": "https://api-prod.bgchprod.info/omnia/nodes/receiver-downstairs",
+		"name": "Receiver 1",
+		"parentNodeId": "hub",
+		"attributes": {
+			"nodeType": {"reportedValue": "http://alertme.com/schema/json/node.class.thermostat.json#"}
+		}
+	}, {
+		"id": "heating-downstairs",
+		"href": "https://api-prod.bgchprod.info/omnia/nodes/heating-downstairs",
+		"name": "Downstairs",
+		"parentNodeId": "receiver-downstairs",
+		"attributes": {
+			"nodeType": {"reportedValue": "http://alertme.com/schema/json/node.class.thermostat.json#"},
+			"temperature": {"reportedValue": 19.5}
+		}
+	}, {
+		"id": "water-downstairs",
+		"href": "https://api-prod.bgchprod.info/omnia/nodes/water-downstairs",
+		"name": "Hot Water",
+		"parentNodeId": "receiver-downstairs",
+		"attributes": {
+			"nodeType": {"reportedValue": "http://alertme.com/schema/json/node.class.thermostat.json#"},
+			"stateHotWaterRelay": {"reportedValue": "OFF"}
+		}
+	}, {
+		"id": "receiver-upstairs",
+		"href": "https://api-prod.bgchprod.info/omnia/nodes/receiver-upstairs",
+		"name": "Receiver 2",
+		"parentNodeId": "hub",
+		"attributes": {
+			"nodeType": {"reportedValue": "http://alertme.com/schema/json/node.class.thermostat.json#"}
+		}
+	}, {
+		"id": "heating-upstairs",
+		"href": "https://api-prod.bgchprod.info/omnia/nodes/heating-upstairs",
+		"name": "Upstairs",
+		"parentNodeId": "receiver-upstairs",
+		"attributes": {
+			"nodeType": {"reportedValue": "http://alertme.com/schema/json/node.class.thermostat.json#"},
+			"temperature": {"reportedValue": 18.0}
+		}
+	}]
+}`
+
+func zonesHome(body string) (*Home, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/omnia/nodes" {
+			http.Error(w, "unknown path", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.alertme.zoo-6.1+json;charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, body)
+	}))
+
+	baseURL, _ := url.Parse(srv.URL)
+	return &Home{baseURL: baseURL, httpClient: srv.Client()}, srv.Close
+}
+
+func TestHome_Zones(t *testing.T) {
+	home, done := zonesHome(zonesJSON)
+	defer done()
+
+	zones, err := home.Zones()
+	if err != nil {
+		t.Fatalf("Home.Zones() error = %v, want nil", err)
+	}
+
+	type zoneIDs struct {
+		hub, receiver, thermostat, controller, hotWater string
+	}
+
+	ids := func(z *Zone) zoneIDs {
+		var got zoneIDs
+		if z.Hub != nil {
+			got.hub = z.Hub.ID
+		}
+		if z.Receiver != nil {
+			got.receiver = z.Receiver.ID
+		}
+		if z.Thermostat != nil {
+			got.thermostat = z.Thermostat.ID
+		}
+		if z.Controller != nil {
+			got.controller = z.Controller.ID
+		}
+		if z.HotWater != nil {
+			got.hotWater = z.HotWater.ID
+		}
+		return got
+	}
+
+	want := []zoneIDs{
+		{"hub", "receiver-downstairs", "heating-downstairs", "ui-downstairs", "water-downstairs"},
+		{"hub", "receiver-upstairs", "heating-upstairs", "ui-upstairs", ""},
+	}
+
+	if len(zones) != len(want) {
+		t.Fatalf("Home.Zones() = %v zones, want %v", len(zones), len(want))
+	}
+
+	for i, z := range zones {
+		if got := ids(z); got != want[i] {
+			t.Errorf("Home.Zones()[%d] = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestThermostat_Controller(t *testing.T) {
+	home, done := zonesHome(zonesJSON)
+	defer done()
+
+	tests := []struct {
+		name    string
+		id      string
+		want    string
+		wantErr bool
+	}{
+		{"Downstairs", "heating-downstairs", "ui-downstairs", false},
+		{"Upstairs", "heating-upstairs", "ui-upstairs", false},
+		{"Unknown", "heating-attic", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &Thermostat{ID: tt.id, home: home}
+
+			got, err := ts.Controller()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Thermostat.Controller() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.ID != tt.want {
+				t.Errorf("Thermostat.Controller() = %v, want %v", got.ID, tt.want)
+			}
+		})
+	}
+}
+
+func TestHome_Zones_singleUnbound(t *testing.T) {
+	home, done := zonesHome(`{
+		"nodes": [{
+			"id": "ui",
+			"name": "Thermostat",
+			"attributes": {
+				"nodeType": {"reportedValue": "http://alertme.com/schema/json/node.class.thermostatui.json#"}
+			}
+		}, {
+			"id": "heating",
+			"name": "Receiver 1",
+			"attributes": {
+				"nodeType": {"reportedValue": "http://alertme.com/schema/json/node.class.thermostat.json#"},
+				"temperature": {"reportedValue": 19.5}
+			}
+		}]
+	}`)
+	defer done()
+
+	zones, err := home.Zones()
+	if err != nil {
+		t.Fatalf("Home.Zones() error = %v, want nil", err)
+	}
+
+	if len(zones) != 1 || zones[0].Controller == nil || zones[0].Controller.ID != "ui" {
+		t.Errorf("Home.Zones() = %+v, want single zone paired with ui", zones)
+	}
+
+	if zones[0].Receiver != nil || zones[0].Hub != nil {
+		t.Errorf("Home.Zones() receiver = %v, hub = %v, want nil", zones[0].Receiver, zones[0].Hub)
+	}
+}
@@ -0,0 +1,205 @@
+package httpkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Cassette is a recorded sequence of HTTP interactions
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request as stored in a Cassette
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response as stored in a Cassette
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// RecordingTransport is a HTTP RoundTripper which records each request
+// and response sent through it to a cassette file. Credentials and
+// session tokens are redacted wherever they appear.
+type RecordingTransport struct {
+	path string
+	rt   http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	secrets  secrets
+}
+
+// NewRecordingTransport returns a RecordingTransport which sends requests
+// with rt and records them to the cassette file at path. The cassette is
+// rewritten after every interaction.
+func NewRecordingTransport(path string, rt http.RoundTripper) *RecordingTransport {
+	return &RecordingTransport{path: path, rt: rt}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RecordingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if r.Body != nil {
+		b, err := ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		reqBody = b
+		r = r.Clone(r.Context())
+		r.Body = ioutil.NopCloser(bytes.NewReader(b))
+	}
+
+	resp, err := t.rt.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.secrets.addHeader(r.Header)
+	t.secrets.addBody(reqBody)
+	t.secrets.addBody(respBody)
+
+	t.cassette.Interactions = append(t.cassette.Interactions, Interaction{
+		Request: RecordedRequest{
+			Method: r.Method,
+			URL:    t.secrets.redact(r.URL.String()),
+			Header: t.secrets.redactHeader(r.Header),
+			Body:   t.secrets.redactBody(reqBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     t.secrets.redactHeader(resp.Header),
+			Body:       t.secrets.redactBody(respBody),
+		},
+	})
+
+	if err := t.save(); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// save atomically writes the cassette to its file
+func (t *RecordingTransport) save() error {
+	b, err := json.MarshalIndent(&t.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(t.path), filepath.Base(t.path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), t.path)
+}
+
+// ReplayTransport is a HTTP RoundTripper which serves the responses
+// recorded in a cassette file. Each request is answered by the first
+// unused interaction with the same method, path and query.
+type ReplayTransport struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayTransport returns a ReplayTransport serving the cassette file
+// at path.
+func NewReplayTransport(path string) (*ReplayTransport, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Cassette
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("httpkit: invalid cassette %s: %v", path, err)
+	}
+
+	return &ReplayTransport{
+		interactions: c.Interactions,
+		used:         make([]bool, len(c.Interactions)),
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *ReplayTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Body != nil {
+		r.Body.Close()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, in := range t.interactions {
+		if t.used[i] || in.Request.Method != r.Method {
+			continue
+		}
+
+		u, err := r.URL.Parse(in.Request.URL)
+		if err != nil || u.Path != r.URL.Path || u.RawQuery != r.URL.RawQuery {
+			continue
+		}
+
+		t.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       r,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("httpkit: no recorded interaction for %s %s", r.Method, r.URL)
+}
@@ -0,0 +1,165 @@
+package httpkit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/geoffgarside/homekit-hive/pkg/httpkit"
+)
+
+func TestRecordAndReplayTransport(t *testing.T) {
+	const (
+		password = "s3cr3t-password"
+		token    = "4wdz82NrUmdYCuuNz3wzofWGymjRWigL"
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/omnia/auth/sessions":
+			w.Write([]byte(`{"sessions":[{"id":"` + token + `","sessionId":"` + token + `"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/omnia/nodes":
+			if r.Header.Get("X-Omnia-Access-Token") != token {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			w.Write([]byte(`{"nodes":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "httpkit-cassette")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir() err = %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "cassette.json")
+
+	roundTrip := func(c *http.Client) []string {
+		var bodies []string
+
+		resp, err := c.Post(srv.URL+"/omnia/auth/sessions", "application/json",
+			strings.NewReader(`{"sessions":[{"username":"user","password":"`+password+`"}]}`))
+		if err != nil {
+			t.Fatalf("http.Client.Post() err = %v, want nil", err)
+		}
+
+		b, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		bodies = append(bodies, string(b))
+
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/omnia/nodes", nil)
+		req.Header.Set("X-Omnia-Access-Token", token)
+
+		resp, err = c.Do(req)
+		if err != nil {
+			t.Fatalf("http.Client.Do() err = %v, want nil", err)
+		}
+
+		b, _ = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		return append(bodies, resp.Status, string(b))
+	}
+
+	recorded := roundTrip(&http.Client{
+		Transport: httpkit.NewRecordingTransport(path, http.DefaultTransport),
+	})
+
+	if recorded[1] != "200 OK" {
+		t.Errorf("recorded status = %v, want 200 OK", recorded[1])
+	}
+
+	cassette, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ioutil.ReadFile() err = %v, want nil", err)
+	}
+
+	for _, secret := range []string{password, token} {
+		if strings.Contains(string(cassette), secret) {
+			t.Errorf("cassette contains %q, want redacted", secret)
+		}
+	}
+
+	rt, err := httpkit.NewReplayTransport(path)
+	if err != nil {
+		t.Fatalf("httpkit.NewReplayTransport() err = %v, want nil", err)
+	}
+
+	replayed := roundTrip(&http.Client{Transport: rt})
+
+	if replayed[1] != recorded[1] || replayed[2] != recorded[2] {
+		t.Errorf("replayed = %q, want %q", replayed[1:], recorded[1:])
+	}
+
+	if strings.Contains(replayed[0], token) || !strings.Contains(replayed[0], httpkit.Redacted) {
+		t.Errorf("replayed login = %v, want redacted session", replayed[0])
+	}
+
+	if _, err := (&http.Client{Transport: rt}).Get(srv.URL + "/omnia/nodes"); err == nil {
+		t.Errorf("replay of exhausted cassette err = %v, want != nil", err)
+	}
+}
+
+func TestRecordingTransport_EscapedPassword(t *testing.T) {
+	const password = `se"cr\et` + "\t<&>"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"sessions":[]}`))
+	}))
+
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "httpkit-cassette")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir() err = %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "cassette.json")
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"sessions": []map[string]string{{"username": "user", "password": password}},
+	})
+
+	c := &http.Client{Transport: httpkit.NewRecordingTransport(path, http.DefaultTransport)}
+
+	resp, err := c.Post(srv.URL+"/omnia/auth/sessions", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("http.Client.Post() err = %v, want nil", err)
+	}
+
+	resp.Body.Close()
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ioutil.ReadFile() err = %v, want nil", err)
+	}
+
+	var cassette httpkit.Cassette
+	if err := json.Unmarshal(b, &cassette); err != nil || len(cassette.Interactions) != 1 {
+		t.Fatalf("cassette = %s, %v, want 1 interaction", b, err)
+	}
+
+	recorded := cassette.Interactions[0].Request.Body
+	if strings.Contains(recorded, "cr") || strings.Contains(string(b), "cr\\") {
+		t.Errorf("recorded body = %v, want password redacted", recorded)
+	}
+
+	if !strings.Contains(recorded, `"password":"`+httpkit.Redacted+`"`) {
+		t.Errorf("recorded body = %v, want redacted password field", recorded)
+	}
+}
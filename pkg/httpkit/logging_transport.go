@@ -0,0 +1,82 @@
+package httpkit
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Logger is the interface used by LoggingTransport to write its logs, it
+// is satisfied by *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// LoggerFunc adapts a printf style function, such as a leveled logging
+// method, into a Logger.
+type LoggerFunc func(format string, v ...interface{})
+
+// Printf calls f(format, v...)
+func (f LoggerFunc) Printf(format string, v ...interface{}) {
+	f(format, v...)
+}
+
+// LoggingTransport is a HTTP RoundTripper which logs the method, URL,
+// status and latency of all requests sent through it. The request and
+// response bodies are also logged when bodies is true. Credentials and
+// session tokens are redacted from everything logged.
+func LoggingTransport(logger Logger, bodies bool, rt http.RoundTripper) http.RoundTripper {
+	return httpRoundTripper(func(r *http.Request) (*http.Response, error) {
+		var s secrets
+		s.addHeader(r.Header)
+
+		var reqBody []byte
+		if bodies && r.Body != nil {
+			b, err := ioutil.ReadAll(r.Body)
+			r.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+
+			reqBody = b
+			r = r.Clone(r.Context())
+			r.Body = ioutil.NopCloser(bytes.NewReader(b))
+			s.addBody(reqBody)
+		}
+
+		start := time.Now()
+		resp, err := rt.RoundTrip(r)
+		latency := time.Since(start)
+
+		url := s.redact(r.URL.String())
+
+		if err != nil {
+			logger.Printf("http: %s %s failed after %v: %v", r.Method, url, latency, s.redact(err.Error()))
+			return nil, err
+		}
+
+		logger.Printf("http: %s %s %s in %v", r.Method, url, resp.Status, latency)
+
+		if !bodies {
+			return resp, nil
+		}
+
+		respBody, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+		s.addBody(respBody)
+
+		if len(reqBody) > 0 {
+			logger.Printf("http: %s %s request body: %s", r.Method, url, s.redactBody(reqBody))
+		}
+
+		logger.Printf("http: %s %s response body: %s", r.Method, url, s.redactBody(respBody))
+
+		return resp, nil
+	})
+}
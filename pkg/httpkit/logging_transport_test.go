@@ -0,0 +1,126 @@
+package httpkit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/geoffgarside/homekit-hive/pkg/httpkit"
+)
+
+func TestLoggingTransport(t *testing.T) {
+	const (
+		password = "s3cr3t-password"
+		token    = "4wdz82NrUmdYCuuNz3wzofWGymjRWigL"
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		if !strings.Contains(string(b), password) {
+			http.Error(w, "password not forwarded", http.StatusBadRequest)
+			return
+		}
+
+		w.Write([]byte(`{"sessions":[{"id":"` + token + `","sessionId":"` + token + `"}]}`))
+	}))
+
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		bodies bool
+		lines  int
+	}{
+		{"Without Bodies", false, 1},
+		{"With Bodies", true, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs []string
+
+			c := &http.Client{
+				Transport: httpkit.LoggingTransport(httpkit.LoggerFunc(func(format string, v ...interface{}) {
+					logs = append(logs, fmt.Sprintf(format, v...))
+				}), tt.bodies, http.DefaultTransport),
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, srv.URL+"/omnia/auth/sessions/"+token,
+				strings.NewReader(`{"sessions":[{"username":"user","password":"`+password+`"}]}`))
+			req.Header.Set("X-Omnia-Access-Token", token)
+
+			resp, err := c.Do(req)
+			if err != nil {
+				t.Fatalf("http.Client.Do() err = %v, want nil", err)
+			}
+
+			body, _ := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), token) {
+				t.Errorf("response = %v %s, want 200 with session", resp.Status, body)
+			}
+
+			if len(logs) != tt.lines {
+				t.Fatalf("logs = %q, want %v lines", logs, tt.lines)
+			}
+
+			if !strings.Contains(logs[0], "POST") || !strings.Contains(logs[0], "200 OK") {
+				t.Errorf("logs[0] = %v, want method and status", logs[0])
+			}
+
+			for _, line := range logs {
+				if strings.Contains(line, password) || strings.Contains(line, token) {
+					t.Errorf("log %q contains a secret, want redacted", line)
+				}
+			}
+		})
+	}
+}
+
+func TestLoggingTransport_EscapedPassword(t *testing.T) {
+	const password = `se"cr\et` + "\t<&>"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"sessions":[]}`))
+	}))
+
+	defer srv.Close()
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"sessions": []map[string]string{{"username": "user", "password": password}},
+	})
+
+	var logs []string
+
+	c := &http.Client{
+		Transport: httpkit.LoggingTransport(httpkit.LoggerFunc(func(format string, v ...interface{}) {
+			logs = append(logs, fmt.Sprintf(format, v...))
+		}), true, http.DefaultTransport),
+	}
+
+	resp, err := c.Post(srv.URL+"/omnia/auth/sessions", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("http.Client.Post() err = %v, want nil", err)
+	}
+
+	resp.Body.Close()
+
+	escaped, _ := json.Marshal(password)
+	for _, line := range logs {
+		for _, secret := range []string{password, string(escaped[1 : len(escaped)-1]), `se\"cr`} {
+			if strings.Contains(line, secret) {
+				t.Errorf("log %q contains the password, want redacted", line)
+			}
+		}
+	}
+
+	if len(logs) != 3 || !strings.Contains(logs[1], `"password":"`+httpkit.Redacted+`"`) {
+		t.Errorf("logs = %q, want redacted request body", logs)
+	}
+}
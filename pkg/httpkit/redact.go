@@ -0,0 +1,140 @@
+package httpkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Redacted replaces secret values in recorded and logged requests
+const Redacted = "REDACTED"
+
+// redactedHeaders are the headers whose values are always redacted
+var redactedHeaders = []string{
+	"Authorization",
+	"X-Omnia-Access-Token",
+}
+
+// redactedFields are the JSON body fields whose values are always redacted
+var redactedFields = map[string]bool{
+	"password":  true,
+	"sessionId": true,
+	"token":     true,
+}
+
+// secrets is the set of values redacted wherever they appear, including
+// URLs and the bodies of later requests.
+type secrets []string
+
+func (s *secrets) add(v string) {
+	if v == "" || v == Redacted {
+		return
+	}
+
+	for _, secret := range *s {
+		if secret == v {
+			return
+		}
+	}
+
+	*s = append(*s, v)
+}
+
+// addHeader collects the values of redacted headers
+func (s *secrets) addHeader(h http.Header) {
+	for _, name := range redactedHeaders {
+		s.add(h.Get(name))
+	}
+}
+
+// addBody collects the values of redacted fields in a JSON body
+func (s *secrets) addBody(body []byte) {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return
+	}
+
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for k, e := range v {
+				if str, ok := e.(string); ok && redactedFields[k] {
+					s.add(str)
+				}
+
+				walk(e)
+			}
+		case []interface{}:
+			for _, e := range v {
+				walk(e)
+			}
+		}
+	}
+
+	walk(v)
+}
+
+func (s secrets) redact(v string) string {
+	for _, secret := range s {
+		v = strings.Replace(v, secret, Redacted, -1)
+	}
+
+	return v
+}
+
+// redactBody redacts a request or response body. JSON bodies are
+// decoded so the values of redacted fields are replaced whatever escaping
+// they were sent with, and re-encoded. Other bodies fall back to redact.
+func (s secrets) redactBody(body []byte) string {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return s.redact(string(body))
+	}
+
+	var walk func(v interface{}) interface{}
+	walk = func(v interface{}) interface{} {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for k, e := range v {
+				if _, ok := e.(string); ok && redactedFields[k] {
+					v[k] = Redacted
+				} else {
+					v[k] = walk(e)
+				}
+			}
+		case []interface{}:
+			for i, e := range v {
+				v[i] = walk(e)
+			}
+		case string:
+			return s.redact(v)
+		}
+
+		return v
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(walk(v)); err != nil {
+		return s.redact(string(body))
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func (s secrets) redactHeader(h http.Header) http.Header {
+	redacted := make(http.Header, len(h))
+	for k, vs := range h {
+		for _, v := range vs {
+			redacted.Add(k, s.redact(v))
+		}
+	}
+
+	return redacted
+}